@@ -2,4 +2,8 @@ Rem rsrc -manifest ./cmd/abyss-blackbox/main.manifest -ico ./trig_96x96.ico -o .
 Rem go build -ldflags="-H windowsgui" -o abyss-blackbox.exe
 go generate ./...
 go build -trimpath -ldflags="-H windowsgui -s -w" -o abyss-blackbox.exe ./cmd/abyss-blackbox 
-go build -trimpath -ldflags="-s -w" ./cmd/extract/
\ No newline at end of file
+go build -trimpath -ldflags="-s -w" ./cmd/extract/
+go build -trimpath -ldflags="-s -w" ./cmd/analyze/
+go build -trimpath -ldflags="-s -w" ./cmd/recover/
+go build -trimpath -ldflags="-s -w" ./cmd/abyss-history/
+go build -trimpath -ldflags="-s -w" ./cmd/abyss-upload/
\ No newline at end of file
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/internal/index"
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+func main() {
+	folder := flag.String("folder", filepath.Join(filepath.Dir(os.Args[0]), "recordings"), "recordings folder")
+	from := flag.String("from", "", "only recordings started on this day (YYYY-MM-DD) or later")
+	to := flag.String("to", "", "only recordings started on this day (YYYY-MM-DD) or earlier")
+	character := flag.String("character", "", "only recordings of character")
+	ship := flag.String("ship", "", "only recordings with ship name or abyss ship type (cruiser, destroyer, frigate)")
+	tier := flag.Int("tier", index.AnyTier, "only recordings of abyss tier (0-6), known for recordings with abyss type override or detected abyss type")
+	weather := flag.String("weather", "", "only recordings of weather, known for recordings with abyss type override or detected abyss type")
+	upload := flag.String("upload", "", "only recordings with upload status: uploaded, failed, pending, not-uploaded, unknown")
+	sortBy := flag.String("sort", index.SortByDate, "sort by: date, duration, character, tier, weather")
+	reverse := flag.Bool("reverse", false, "reverse sort order")
+	stats := flag.Bool("stats", false, "print aggregate statistics instead of recordings")
+	jsonOutput := flag.Bool("json", false, "output as JSON")
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: abyss-history [-folder recordings] [filters] [-sort date] [-reverse] [-stats] [-json]")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	filter := index.Filter{Character: *character, Ship: *ship, Tier: *tier, Weather: *weather}
+
+	var err error
+
+	if *from != "" {
+		if filter.From, err = time.ParseInLocation(time.DateOnly, *from, time.Local); err != nil {
+			log.Fatalf("invalid -from: %v", err)
+		}
+	}
+
+	if *to != "" {
+		if filter.To, err = time.ParseInLocation(time.DateOnly, *to, time.Local); err != nil {
+			log.Fatalf("invalid -to: %v", err)
+		}
+
+		filter.To = filter.To.AddDate(0, 0, 1)
+	}
+
+	if *upload != "" {
+		status, errr := index.ParseUploadStatus(*upload)
+		if errr != nil {
+			log.Fatal(errr)
+		}
+
+		filter.Upload = &status
+	}
+
+	idx, err := index.Open(*folder)
+	if err != nil {
+		log.Printf("index of %s is damaged, rebuilding: %v", *folder, err)
+		idx = index.New(*folder)
+	}
+
+	if _, _, err = idx.Scan(); err != nil {
+		log.Fatal(err)
+	}
+
+	recordings := index.FilterRecordings(idx.Recordings(), filter)
+
+	if err = index.SortRecordings(recordings, *sortBy, *reverse); err != nil {
+		log.Fatal(err)
+	}
+
+	switch {
+	case *stats && *jsonOutput:
+		err = writeJSON(os.Stdout, index.Summarize(recordings))
+	case *stats:
+		err = writeStats(os.Stdout, index.Summarize(recordings))
+	case *jsonOutput:
+		err = writeJSON(os.Stdout, recordings)
+	default:
+		err = writeRecordings(os.Stdout, recordings)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func writeRecordings(w io.Writer, recordings []*index.IndexedRecording) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "DATE\tDURATION\tCHARACTERS\tSHIPS\tABYSS\tWEATHER\tCOMPLETED\tUPLOAD\tFILE")
+
+	for _, r := range recordings {
+		if r.Error != "" {
+			fmt.Fprintf(tw, "%s\t\t\t\t\t\t\t%s\t%s (unreadable: %s)\n", r.RecordedAtTime().Format(time.DateTime), uploadStatus(r), r.Filename, r.Error)
+			continue
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%t\t%s\t%s\n",
+			r.RecordedAtTime().Format(time.DateTime),
+			r.Duration().Round(time.Second),
+			strings.Join(r.Characters, ", "),
+			ships(r),
+			abyssType(r),
+			weatherStrength(r),
+			r.Completed(),
+			uploadStatus(r),
+			r.Filename,
+		)
+	}
+
+	fmt.Fprintf(tw, "\n%d recording(s)\n", len(recordings))
+
+	return tw.Flush()
+}
+
+func writeStats(w io.Writer, s index.Stats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Runs:\t%d\n", s.Runs)
+	fmt.Fprintf(tw, "Completed:\t%d (success rate %.1f%%)\n", s.Completed, s.SuccessRate*100)
+	fmt.Fprintf(tw, "Average duration:\t%s\n", s.AverageDuration.Round(time.Second))
+	fmt.Fprintf(tw, "Uploaded:\t%d (failed %d)\n", s.Uploaded, s.UploadFailed)
+	fmt.Fprintf(tw, "Unreadable:\t%d\n", s.Unreadable)
+
+	if len(s.RunsPerDay) > 0 {
+		fmt.Fprintln(tw, "\nRuns per day:")
+
+		for _, day := range s.RunsPerDay {
+			fmt.Fprintf(tw, "%s\t%d\n", day.Day, day.Runs)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func ships(r *index.IndexedRecording) string {
+	names := make([]string, 0, len(r.Fittings))
+	for _, fit := range r.Fittings {
+		names = append(names, fit.ShipName)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func abyssType(r *index.IndexedRecording) string {
+	if !r.HasAbyssType() {
+		return "-"
+	}
+
+	if r.AbyssShipType == encoding.AbyssRecording_UNKNOWN_SHIP {
+		return fmt.Sprintf("T%d", r.AbyssTier)
+	}
+
+	return fmt.Sprintf("%s T%d", strings.ToLower(r.AbyssShipType.String()), r.AbyssTier)
+}
+
+func weatherStrength(r *index.IndexedRecording) string {
+	weather := r.AbyssWeather
+	if !r.HasAbyssType() || weather == "" {
+		weather = "-"
+	}
+
+	if r.WeatherStrength == 0 {
+		return weather
+	}
+
+	return fmt.Sprintf("%s %d%%", weather, r.WeatherStrength)
+}
+
+func uploadStatus(r *index.IndexedRecording) string {
+	switch r.UploadStatus {
+	case index.IndexedRecording_UPLOADED:
+		return "uploaded"
+	case index.IndexedRecording_UPLOAD_FAILED:
+		return "failed"
+	case index.IndexedRecording_UPLOAD_PENDING:
+		return "pending"
+	case index.IndexedRecording_UPLOAD_UNKNOWN:
+		return "unknown"
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/shivas/abyss-blackbox/internal/app/api/client"
+	"github.com/shivas/abyss-blackbox/internal/charmanager"
+	"github.com/shivas/abyss-blackbox/internal/config"
+	"github.com/shivas/abyss-blackbox/internal/index"
+	"github.com/shivas/abyss-blackbox/internal/uploader"
+)
+
+func main() {
+	folder := flag.String("folder", "", "recordings folder (default: recordings folder from settings)")
+	character := flag.String("character", "", "character to upload recordings as (default: active character from settings)")
+	concurrency := flag.Int("concurrency", uploader.DefaultConcurrency, "how many recordings to upload at once")
+	dryRun := flag.Bool("dry-run", false, "only list recordings that would be uploaded")
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: abyss-upload [-folder recordings] [-character name] [-concurrency 3] [-dry-run]")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	settings, err := config.Read()
+	if err != nil {
+		log.Fatalf("failed reading settings: %v", err)
+	}
+
+	if *folder == "" {
+		*folder = settings.Recordings
+	}
+
+	idx, err := index.Open(*folder)
+	if err != nil {
+		log.Printf("index of %s is damaged, rebuilding: %v", *folder, err)
+		idx = index.New(*folder)
+	}
+
+	if _, _, err = idx.Scan(); err != nil {
+		log.Fatal(err)
+	}
+
+	filenames := idx.NotUploaded()
+	if len(filenames) == 0 {
+		fmt.Println("All recordings are uploaded already")
+		return
+	}
+
+	if *dryRun {
+		for _, filename := range filenames {
+			fmt.Println(filename)
+		}
+
+		fmt.Printf("%d recording(s) not uploaded\n", len(filenames))
+
+		return
+	}
+
+	charManager := charmanager.New(func(string, string) {})
+	if err = charManager.LoadCache(); err != nil {
+		log.Fatalf("failed loading characters, add character in recorder first: %v", err)
+	}
+
+	if err = activateCharacter(charManager, *character, settings.ActiveCharacter); err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	fmt.Printf("Uploading %d recording(s) as %s\n", len(filenames), charManager.ActiveCharacter().CharacterName)
+
+	results := uploader.UploadAll(ctx, client.New(charManager), filenames, *concurrency, func(p uploader.Progress) {
+		status := "ok"
+		if p.Result.Err != nil {
+			status = p.Result.Err.Error()
+		}
+
+		fmt.Printf("[%d/%d] %s: %s\n", p.Done, p.Total, p.Result.Filename, status)
+	})
+
+	uploaded, failed := 0, 0
+
+	for _, result := range results {
+		if result.Attempts == 0 {
+			continue // cancelled before upload started
+		}
+
+		if result.Err != nil {
+			failed++
+		} else {
+			uploaded++
+		}
+
+		if err = idx.SetUploaded(result.Filename, result.Err); err != nil {
+			log.Printf("failed updating recordings index: %v", err)
+		}
+	}
+
+	fmt.Printf("Uploaded %d recording(s), %d failed\n", uploaded, failed)
+
+	if failed > 0 || ctx.Err() != nil {
+		cancel()
+		os.Exit(1)
+	}
+}
+
+// activateCharacter activates character by name, or character active in recorder when name is empty.
+func activateCharacter(charManager *charmanager.CharManager, name string, activeCharacter int32) error {
+	if name == "" {
+		if err := charManager.SetActiveCharacter(activeCharacter); err != nil {
+			return fmt.Errorf("no active character in settings, use -character: %w", err)
+		}
+
+		return nil
+	}
+
+	for _, char := range charManager.Characters() {
+		if strings.EqualFold(char.CharacterName, name) {
+			return charManager.SetActiveCharacter(char.CharacterID)
+		}
+	}
+
+	return fmt.Errorf("character %q is not added in recorder", name)
+}
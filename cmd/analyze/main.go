@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/shivas/abyss-blackbox/pkg/analysis"
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+	"github.com/shivas/abyss-blackbox/pkg/prices"
+)
+
+func main() {
+	jsonOutput := flag.Bool("json", false, "output report as JSON, one document per line for every recording")
+	interval := flag.Duration("interval", analysis.DefaultDPSInterval, "width of DPS timeline bucket")
+	pricesFile := flag.String("prices", "", "price file (CSV or JSON) to value loot and fittings with")
+	pricesURL := flag.String("prices-url", "", "price service to value loot and fittings with, asked for items missing in price file")
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: analyze [-json] [-interval 10s] [-prices prices.csv] [-prices-url url] recording.abyss [recording2.abyss ...]")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	priceProvider, err := prices.NewProvider(nil, *pricesFile, *pricesURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	failed := false
+
+	for i, filename := range flag.Args() {
+		report, err := analyze(filename, analysis.Options{DPSInterval: *interval}, priceProvider)
+		if err != nil {
+			log.Printf("%s: %v", filename, err)
+
+			failed = true
+
+			continue
+		}
+
+		if *jsonOutput {
+			err = encoder.Encode(report)
+		} else {
+			if i > 0 {
+				fmt.Println()
+			}
+
+			err = analysis.WriteText(os.Stdout, report)
+		}
+
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func analyze(filename string, opts analysis.Options, priceProvider prices.PriceProvider) (*analysis.Report, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	abyssFile, err := encoding.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := analysis.Analyze(abyssFile, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Filename = filename
+
+	if priceProvider != nil {
+		if report.Value, err = analysis.Valuate(context.Background(), abyssFile, priceProvider); err != nil {
+			log.Printf("%s: pricing failed, values are incomplete: %v", filename, err)
+		}
+	}
+
+	return report, nil
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/shivas/abyss-blackbox/pkg/analysis"
 	"github.com/shivas/abyss-blackbox/pkg/encoding"
 )
 
@@ -32,6 +33,12 @@ func main() {
 		return
 	}
 
+	overview, err := abyssFile.OverviewGIF()
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
 	gifName := filepath.Base(os.Args[1]) + ".gif"
 
 	gifFile, err := os.Create(gifName)
@@ -41,7 +48,7 @@ func main() {
 	}
 	defer gifFile.Close()
 
-	_, err = io.Copy(gifFile, bytes.NewReader(abyssFile.Overview))
+	_, err = io.Copy(gifFile, bytes.NewReader(overview))
 	if err != nil {
 		log.Print(err)
 		return
@@ -63,6 +70,10 @@ func main() {
 
 	fmt.Printf("Recorded weather strength: %d%% and loot record discriminator: %q\n", abyssFile.WeatherStrength, abyssFile.LootRecordDiscriminator)
 
+	if abyssFile.StartedAt != 0 {
+		fmt.Printf("Recording started: %s, stopped: %s\n", time.UnixMilli(abyssFile.StartedAt).UTC(), time.UnixMilli(abyssFile.StoppedAt).UTC())
+	}
+
 	for _, logRecord := range abyssFile.CombatLog {
 		fmt.Printf("combat log record language for character %q: %s\n", logRecord.CharacterName, logRecord.GetLanguageCode().String())
 
@@ -81,6 +92,16 @@ func main() {
 		f.Close()
 	}
 
+	timeline := analysis.DamageTimeline(abyssFile)
+
+	if err = writeFile(os.Args[1]+".timeline.csv", timeline.WriteCSV); err != nil {
+		log.Println(err)
+	}
+
+	if err = writeFile(os.Args[1]+".timeline.json", timeline.WriteJSON); err != nil {
+		log.Println(err)
+	}
+
 	f, err := os.Create(os.Args[1] + ".loot.txt")
 	if err != nil {
 		log.Println(err)
@@ -89,8 +110,29 @@ func main() {
 	fmt.Fprintf(f, "Loot recordings:")
 
 	for _, lootRecord := range abyssFile.Loot {
-		fmt.Fprintf(f, "time: %s\n%s\n\n", time.Duration(lootRecord.Frame)*time.Second, lootRecord.Loot)
+		offset := time.Duration(lootRecord.Frame) * time.Second
+
+		if first, ok := abyssFile.FrameTime(0); ok && lootRecord.Timestamp != 0 {
+			offset = time.UnixMilli(lootRecord.Timestamp).Sub(first).Truncate(time.Second)
+		}
+
+		fmt.Fprintf(f, "time: %s\n%s\n\n", offset, lootRecord.Loot)
 	}
 
 	f.Close()
 }
+
+// writeFile creates file and writes it using write.
+func writeFile(filename string, write func(io.Writer) error) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err = write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+func main() {
+	output := flag.String("o", "", "output filename (default: input filename with .recovered.abyss extension)")
+	codecName := flag.String("codec", "gzip", "compression codec of recovered recording: "+codecNames())
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: recover [-o output.abyss] [-codec gzip] damaged.abyss|recording.abyss.journal")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	filename := flag.Arg(0)
+
+	codec, err := encoding.ParseCodec(*codecName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	outputName := *output
+	if outputName == "" {
+		outputName = strings.TrimSuffix(strings.TrimSuffix(filename, encoding.JournalExtension), ".abyss") + ".recovered.abyss"
+	}
+
+	abyssFile, err := recoverFile(filename)
+	if err != nil {
+		log.Fatalf("%s: %v", filename, err)
+	}
+
+	file, err := os.Create(outputName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = abyssFile.Encode(file, encoding.WithCodec(codec)); err != nil {
+		file.Close()
+		log.Fatal(err)
+	}
+
+	if err = file.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("recovered recording written to %s\n", outputName)
+}
+
+func codecNames() string {
+	var names []string
+	for _, c := range encoding.Codecs() {
+		names = append(names, c.String())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func recoverFile(filename string) (*encoding.AbyssRecording, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	br := bufio.NewReader(file)
+
+	journal, err := encoding.ReadJournal(br)
+	if err == nil {
+		return recoverJournal(journal)
+	}
+
+	if err != encoding.ErrNotJournal {
+		return nil, err
+	}
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	abyssFile, report, err := encoding.Recover(file)
+
+	printReport(report)
+
+	return abyssFile, err
+}
+
+func recoverJournal(journal *encoding.Journal) (*encoding.AbyssRecording, error) {
+	fmt.Println("file is recording journal")
+
+	if journal.Truncated {
+		fmt.Println("journal ends with incomplete entry, it was skipped")
+	}
+
+	for _, err := range journal.ReadMissingCombatLogs() {
+		fmt.Printf("combat log could not be read: %v\n", err)
+	}
+
+	abyssFile, err := journal.Finalize(encoding.OverviewFormatGIF)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("frames: %d, loot records: %d, combat log records: %d\n", len(journal.Frames), len(abyssFile.Loot), len(abyssFile.CombatLog))
+
+	return abyssFile, nil
+}
+
+func printReport(report *encoding.RecoveryReport) {
+	if report.CompressionError != "" {
+		fmt.Printf("compressed stream is damaged: %s\n", report.CompressionError)
+	}
+
+	if report.BytesExpected > 0 {
+		fmt.Printf("uncompressed bytes read: %d of %d\n", report.BytesRecovered, report.BytesExpected)
+	} else {
+		fmt.Printf("uncompressed bytes read: %d\n", report.BytesRecovered)
+	}
+
+	if report.ChecksumMismatch {
+		fmt.Println("data doesn't match checksum stored in header")
+	}
+
+	if len(report.RecoveredFields) > 0 {
+		fmt.Printf("recovered fields: %s\n", strings.Join(report.RecoveredFields, ", "))
+	}
+
+	if report.TruncatedField != "" {
+		fmt.Printf("field %q was cut off, any fields after it are lost\n", report.TruncatedField)
+	}
+
+	if len(report.CorruptedFields) > 0 {
+		fmt.Printf("corrupted fields dropped: %s\n", strings.Join(report.CorruptedFields, ", "))
+	}
+
+	if report.OverviewTruncated {
+		fmt.Println("overview was cut after last complete frame")
+	}
+
+	fmt.Printf("frames: %d, loot records: %d, combat log records: %d\n", report.FramesRecovered, report.LootRecords, report.CombatLogRecords)
+
+	if report.Lossless() {
+		fmt.Println("nothing was lost, recording was not damaged")
+	}
+}
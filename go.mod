@@ -5,6 +5,7 @@ go 1.21
 require (
 	github.com/disintegration/gift v1.2.1
 	github.com/disintegration/imaging v1.6.2
+	github.com/klauspost/compress v1.17.11
 	github.com/lxn/walk v0.0.0-20210112085537-c389da54e794
 	github.com/lxn/win v0.0.0-20210218163916-a377121e959e
 	github.com/pkg/browser v0.0.0-20210911075715-681adbf594b8
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"
+	"log"
+	"runtime"
+	"sync"
+
+	"github.com/lxn/walk"
+
+	"github.com/shivas/abyss-blackbox/internal/app/domain"
+	"github.com/shivas/abyss-blackbox/internal/app/recorder"
+	"github.com/shivas/abyss-blackbox/internal/config"
+	"github.com/shivas/abyss-blackbox/internal/fittings"
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+	"github.com/shivas/abyss-blackbox/pkg/inventory"
+	"github.com/shivas/abyss-blackbox/pkg/prices"
+)
+
+// frameSource delivers frames captured by main window draw loop to recorder.
+type frameSource chan domain.Frame
+
+func (f frameSource) Frames() <-chan domain.Frame {
+	return f
+}
+
+// clipboardSource delivers walk clipboard changes to recorder.
+type clipboardSource chan string
+
+// Listener is clipboard change event handler.
+func (c clipboardSource) Listener() {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	clipboard, err := walk.Clipboard().Text()
+	if err != nil {
+		return
+	}
+
+	select {
+	case c <- clipboard:
+	default:
+		log.Println("not recording, loot capture dropped")
+	}
+}
+
+func (c clipboardSource) Clipboard() <-chan string {
+	return c
+}
+
+// fittingsSource provides fittings assigned in fittings manager.
+type fittingsSource struct {
+	manager *fittings.FittingsManager
+}
+
+func (f fittingsSource) FittingForPilot(character string) *encoding.Fit {
+	fit := f.manager.GetFittingForPilot(character)
+	if fit == nil {
+		return nil
+	}
+
+	return &encoding.Fit{
+		Source:      fit.Source,
+		ForeignID:   fit.ForeignID,
+		FittingName: fit.FittingName,
+		EFT:         fit.EFT,
+		FFH:         fit.FFH,
+		Price:       fit.Price,
+		ShipName:    fit.ShipName,
+		ShipTypeID:  fit.ShipTypeID,
+	}
+}
+
+func (f fittingsSource) ShipGroupForPilot(character string) int32 {
+	return f.manager.GetShipGroupForPilot(character)
+}
+
+// settingsSource provides recorder settings out of application settings.
+type settingsSource struct {
+	config *config.CaptureConfig
+}
+
+func (s settingsSource) RecorderSettings() recorder.Settings {
+	quantities, err := inventory.ParseDiscriminatorQuantities(s.config.LootRecordDiscriminatorQuantities)
+	if err != nil {
+		log.Printf("ignoring loot record discriminator quantities: %v", err)
+	}
+
+	var codec encoding.Codec
+
+	if s.config.RecordingCodec != "" {
+		if codec, err = encoding.ParseCodec(s.config.RecordingCodec); err != nil {
+			log.Printf("recording with default codec: %v", err)
+		}
+	}
+
+	return recorder.Settings{
+		Recordings:              s.config.Recordings,
+		TestServer:              s.config.TestServer,
+		LootRecordDiscriminator: s.config.LootRecordDiscriminator,
+		DiscriminatorQuantities: quantities,
+		AbyssTypeOverride:       s.config.AbyssTypeOverride,
+		AbyssShipType:           s.config.AbyssShipType,
+		AbyssTier:               s.config.AbyssTier,
+		AbyssWeather:            s.config.AbyssWeather,
+		CompactOverview:         s.config.CompactOverview,
+		RecordAnyClipboard:      s.config.RecordAnyClipboard,
+		Codec:                   codec,
+	}
+}
+
+// priceSource provides prices of price file and price service chosen in settings, price file is loaded again when setting changes.
+type priceSource struct {
+	config *config.CaptureConfig
+
+	mu       sync.Mutex
+	filename string
+	url      string
+	loaded   bool
+	provider prices.PriceProvider
+	err      error
+}
+
+// Configured tells whether any price source is chosen in settings.
+func (p *priceSource) Configured() bool {
+	return p.config.PricesFile != "" || p.config.PricesURL != ""
+}
+
+// Prices satisfies prices.PriceProvider interface.
+func (p *priceSource) Prices(ctx context.Context, names []string) (prices.Prices, error) {
+	p.mu.Lock()
+
+	if !p.loaded || p.filename != p.config.PricesFile || p.url != p.config.PricesURL {
+		p.filename, p.url, p.loaded = p.config.PricesFile, p.config.PricesURL, true
+		p.provider, p.err = prices.NewProvider(nil, p.filename, p.url)
+	}
+
+	provider, err := p.provider, p.err
+	p.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if provider == nil {
+		return prices.Prices{}, nil
+	}
+
+	return provider.Prices(ctx, names)
+}
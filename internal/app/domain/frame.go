@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"image"
+	"time"
+)
+
+// Frame is overview frame captured for recording.
+type Frame struct {
+	Image      *image.Paletted
+	CapturedAt time.Time
+}
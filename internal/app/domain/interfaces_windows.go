@@ -0,0 +1,9 @@
+package domain
+
+import (
+	"syscall"
+)
+
+type ServerProvider interface {
+	IsTestingServer(handle syscall.Handle) bool
+}
@@ -0,0 +1,127 @@
+package events
+
+import (
+	"sync"
+)
+
+// Bus delivers published events to all subscribers.
+// Every subscriber has own queue and goroutine, so slow subscriber never blocks publisher or other subscribers.
+type Bus struct {
+	mu          sync.Mutex
+	subscribers map[int]*subscriber
+	nextID      int
+	closed      bool
+	running     sync.WaitGroup
+}
+
+type subscriber struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []Event
+	closed  bool
+	handler func(Event)
+}
+
+// NewBus creates event bus.
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[int]*subscriber)}
+}
+
+// Subscribe registers handler called for every event published after subscription, in order of publishing.
+// Returned function unsubscribes handler, events already queued are still delivered.
+func (b *Bus) Subscribe(handler func(Event)) (unsubscribe func()) {
+	s := &subscriber{handler: handler}
+	s.cond = sync.NewCond(&s.mu)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return func() {}
+	}
+
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = s
+
+	b.running.Add(1)
+
+	go func() {
+		defer b.running.Done()
+		s.run()
+	}()
+
+	return func() {
+		b.mu.Lock()
+		delete(b.subscribers, id)
+		b.mu.Unlock()
+
+		s.close()
+	}
+}
+
+// Publish queues event for every subscriber, it never blocks on subscribers.
+func (b *Bus) Publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, s := range b.subscribers {
+		s.push(e)
+	}
+}
+
+// Close stops accepting subscribers and waits until all queued events are delivered.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	b.closed = true
+	subscribers := b.subscribers
+	b.subscribers = make(map[int]*subscriber)
+	b.mu.Unlock()
+
+	for _, s := range subscribers {
+		s.close()
+	}
+
+	b.running.Wait()
+}
+
+func (s *subscriber) push(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	s.queue = append(s.queue, e)
+	s.cond.Signal()
+}
+
+func (s *subscriber) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.cond.Signal()
+}
+
+func (s *subscriber) run() {
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			return
+		}
+
+		e := s.queue[0]
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		s.handler(e)
+	}
+}
@@ -0,0 +1,58 @@
+package events
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestBus(t *testing.T) {
+	bus := NewBus()
+
+	var (
+		mu       sync.Mutex
+		received [2][]Event
+	)
+
+	release := make(chan struct{})
+
+	bus.Subscribe(func(e Event) {
+		<-release // slow subscriber must not block publisher or other subscribers
+
+		mu.Lock()
+		received[0] = append(received[0], e)
+		mu.Unlock()
+	})
+
+	unsubscribe := bus.Subscribe(func(e Event) {
+		mu.Lock()
+		received[1] = append(received[1], e)
+		mu.Unlock()
+	})
+
+	published := []Event{
+		RecordingStarted{Filename: "run.abyss"},
+		InitialLootCaptured{Loot: "Quafe\t1"},
+		LootCaptured{Frame: 10, Loot: "Quafe\t1"},
+		RecordingSaved{Filename: "run.abyss", Frames: 11},
+	}
+
+	for _, e := range published[:3] {
+		bus.Publish(e)
+	}
+
+	unsubscribe()
+	bus.Publish(published[3])
+
+	close(release)
+	bus.Close()
+	bus.Publish(UploadFinished{}) // no subscribers after close
+
+	if !reflect.DeepEqual(received[0], published) {
+		t.Errorf("slow subscriber received %+v, want %+v", received[0], published)
+	}
+
+	if !reflect.DeepEqual(received[1], published[:3]) {
+		t.Errorf("unsubscribed subscriber received %+v, want %+v", received[1], published[:3])
+	}
+}
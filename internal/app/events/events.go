@@ -0,0 +1,137 @@
+package events
+
+import (
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+// Event is marker interface of all recorder events.
+type Event interface {
+	isEvent()
+}
+
+// RecordingStarted published when recording starts and initial loot is awaited.
+type RecordingStarted struct {
+	Filename   string
+	Characters []string
+}
+
+// InitialLootCaptured published when cargo before filament activation is captured, recording of frames begins.
+// Character is recorded character cargo was copied from, empty when it could not be resolved.
+type InitialLootCaptured struct {
+	Loot      string
+	Character string
+}
+
+// LootCaptured published for every loot snapshot captured while recording.
+type LootCaptured struct {
+	Frame     int
+	Loot      string
+	Character string
+}
+
+// LootAttributionWarning published when loot record discriminator quantities don't tell which character cargo was copied from.
+type LootAttributionWarning struct {
+	Problem string
+}
+
+// ClipboardIgnored published when clipboard text copied while recording is not inventory copy and is not recorded.
+// Count is number of clipboard changes ignored during recording so far, text itself is never published.
+type ClipboardIgnored struct {
+	Count int
+}
+
+// LootDropped published when clipboard changed while recorder was stopped, Length is length of clipboard text.
+// Clipboard text itself is not carried, it might be anything user copied.
+type LootDropped struct {
+	Length int
+}
+
+// AbyssTypeDetected published when abyss type is detected from filament consumed after initial loot.
+// Conflict is set when it differs from manual override, which is kept in recording.
+type AbyssTypeDetected struct {
+	Filament string
+	ShipType encoding.AbyssRecording_AbyssShipType
+	Tier     int
+	Weather  string
+	// Description is human readable detected abyss type, Override is same for manual override.
+	Description string
+	Override    string
+	Conflict    bool
+}
+
+// WeatherSet published when weather strength is recorded.
+type WeatherSet struct {
+	Strength int
+}
+
+// WeatherReminder published periodically while weather strength is not recorded.
+type WeatherReminder struct {
+	Frames int
+}
+
+// FrameDropped published when frames are missing between two captured frames, or frame could not be written.
+type FrameDropped struct {
+	Count int
+	At    time.Time
+	Err   error
+}
+
+// RecordingSaved published when recording is written to .abyss file.
+type RecordingSaved struct {
+	Filename string
+	Frames   int
+}
+
+// RecordingFailed published when recording was stopped but could not be saved.
+type RecordingFailed struct {
+	Filename string
+	Err      error
+}
+
+// UploadQueued published when recording is added to upload queue.
+type UploadQueued struct {
+	Filename string
+}
+
+// UploadRetrying published when upload attempt failed and recording stays queued for another attempt.
+type UploadRetrying struct {
+	Filename    string
+	Attempts    int
+	NextAttempt time.Time
+	Err         error
+}
+
+// UploadFinished published when recording upload finishes, Err is set when upload failed and won't be retried.
+type UploadFinished struct {
+	Filename string
+	Result   string
+	Err      error
+	// Bulk is set for uploads of bulk upload, progress of which is reported by BulkUploadProgress.
+	Bulk bool
+}
+
+// BulkUploadProgress published after every recording finished uploading during bulk upload.
+type BulkUploadProgress struct {
+	Done   int
+	Total  int
+	Failed int
+}
+
+func (RecordingStarted) isEvent()       {}
+func (InitialLootCaptured) isEvent()    {}
+func (LootCaptured) isEvent()           {}
+func (ClipboardIgnored) isEvent()       {}
+func (LootDropped) isEvent()            {}
+func (LootAttributionWarning) isEvent() {}
+func (AbyssTypeDetected) isEvent()      {}
+func (WeatherSet) isEvent()             {}
+func (WeatherReminder) isEvent()        {}
+func (FrameDropped) isEvent()           {}
+func (RecordingSaved) isEvent()         {}
+func (RecordingFailed) isEvent()        {}
+func (UploadQueued) isEvent()           {}
+func (UploadRetrying) isEvent()         {}
+func (UploadFinished) isEvent()         {}
+func (BulkUploadProgress) isEvent()     {}
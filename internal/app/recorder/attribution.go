@@ -0,0 +1,87 @@
+package recorder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shivas/abyss-blackbox/pkg/inventory"
+)
+
+// discriminatorProblems checks discriminator quantities of recorded characters, every character needs distinct quantity.
+// Single character needs no discriminator, all loot belongs to it.
+func discriminatorProblems(characters []string, discriminator string, quantities map[string]int64) []string {
+	if len(characters) < 2 {
+		return nil
+	}
+
+	var (
+		problems []string
+		owners   = make(map[int64][]string)
+	)
+
+	for _, character := range characters {
+		q, ok := quantities[character]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s has no %s quantity set", character, discriminator))
+			continue
+		}
+
+		owners[q] = append(owners[q], character)
+	}
+
+	collisions := make([]int64, 0, len(owners))
+
+	for q, chars := range owners {
+		if len(chars) > 1 {
+			collisions = append(collisions, q)
+		}
+	}
+
+	sort.Slice(collisions, func(i, j int) bool { return collisions[i] < collisions[j] })
+
+	for _, q := range collisions {
+		problems = append(problems, fmt.Sprintf("%s carry same %s quantity %d", strings.Join(owners[q], ", "), discriminator, q))
+	}
+
+	return problems
+}
+
+// attributeLoot resolves which of recorded characters cargo snapshot was copied from,
+// by quantity of discriminator item in it. Problem is returned when snapshot could not be attributed.
+func attributeLoot(lootSnapshot string, characters []string, discriminator string, quantities map[string]int64) (character, problem string) {
+	if len(characters) == 1 {
+		return characters[0], ""
+	}
+
+	if len(characters) == 0 {
+		return "", ""
+	}
+
+	inv, err := inventory.Parse(lootSnapshot)
+	if err != nil {
+		return "", "" // not a cargo copy, nothing to attribute
+	}
+
+	q := inv.Quantity(discriminator)
+	if q == 0 {
+		return "", fmt.Sprintf("%s missing in copied cargo, loot can't be attributed to character", discriminator)
+	}
+
+	var owners []string
+
+	for _, c := range characters {
+		if quantities[c] == q {
+			owners = append(owners, c)
+		}
+	}
+
+	switch len(owners) {
+	case 0:
+		return "", fmt.Sprintf("no character carries %d %s, loot can't be attributed to character", q, discriminator)
+	case 1:
+		return owners[0], ""
+	default:
+		return "", fmt.Sprintf("%s carry same %s quantity %d, loot can't be attributed to character", strings.Join(owners, ", "), discriminator, q)
+	}
+}
@@ -0,0 +1,50 @@
+package recorder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiscriminatorProblems(t *testing.T) {
+	quantities := map[string]int64{"Runner1": 1, "Runner2": 2, "Runner3": 2}
+
+	got := discriminatorProblems([]string{"Runner1", "Runner2", "Runner3", "Runner4"}, "Quafe", quantities)
+	want := []string{"Runner4 has no Quafe quantity set", "Runner2, Runner3 carry same Quafe quantity 2"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("discriminatorProblems() = %q, want %q", got, want)
+	}
+
+	if got = discriminatorProblems([]string{"Runner4"}, "Quafe", quantities); len(got) != 0 {
+		t.Errorf("discriminatorProblems() of single character = %q", got)
+	}
+}
+
+func TestAttributeLoot(t *testing.T) {
+	characters := []string{"Runner1", "Runner2", "Runner3"}
+	quantities := map[string]int64{"Runner1": 1, "Runner2": 2, "Runner3": 2}
+
+	tests := []struct {
+		name          string
+		loot          string
+		characters    []string
+		wantCharacter string
+		wantProblem   bool
+	}{
+		{name: "resolved", loot: "Quafe\t1\nCalm Dark Filament\t3\n", characters: characters, wantCharacter: "Runner1"},
+		{name: "single character", loot: "Calm Dark Filament\t3\n", characters: []string{"Runner4"}, wantCharacter: "Runner4"},
+		{name: "missing discriminator", loot: "Calm Dark Filament\t3\n", characters: characters, wantProblem: true},
+		{name: "unknown quantity", loot: "Quafe\t5\n", characters: characters, wantProblem: true},
+		{name: "collision", loot: "Quafe\t2\n", characters: characters, wantProblem: true},
+		{name: "not cargo", loot: "https://abyssal.space", characters: characters},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			character, problem := attributeLoot(tt.loot, tt.characters, "Quafe", quantities)
+			if character != tt.wantCharacter || (problem != "") != tt.wantProblem {
+				t.Errorf("attributeLoot() = %q, %q, want %q, problem %t", character, problem, tt.wantCharacter, tt.wantProblem)
+			}
+		})
+	}
+}
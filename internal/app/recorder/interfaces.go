@@ -0,0 +1,70 @@
+package recorder
+
+import (
+	"github.com/shivas/abyss-blackbox/internal/app/domain"
+	"github.com/shivas/abyss-blackbox/internal/app/events"
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+// FrameSource delivers captured overview frames.
+type FrameSource interface {
+	Frames() <-chan domain.Frame
+}
+
+// ClipboardSource delivers clipboard text every time clipboard changes.
+type ClipboardSource interface {
+	Clipboard() <-chan string
+}
+
+// Publisher publishes recorder events to subscribers (overlay, tray notifications, logging).
+type Publisher interface {
+	Publish(e events.Event)
+}
+
+// FittingsSource provides fitting assigned to character, nil if none assigned.
+type FittingsSource interface {
+	FittingForPilot(character string) *encoding.Fit
+	// ShipGroupForPilot returns inventory group of ship fitted for character, 0 if unknown.
+	ShipGroupForPilot(character string) int32
+}
+
+// Settings are recorder settings, read at start of every recording.
+type Settings struct {
+	Recordings              string
+	TestServer              bool
+	LootRecordDiscriminator string
+	// DiscriminatorQuantities are quantities of loot record discriminator carried by each character.
+	DiscriminatorQuantities map[string]int64
+	AbyssTypeOverride       bool
+	AbyssShipType           int
+	AbyssTier               int
+	AbyssWeather            string
+	CompactOverview         bool
+	// RecordAnyClipboard records every clipboard change as loot, otherwise text that is not inventory copy is ignored.
+	RecordAnyClipboard bool
+	// Codec compresses recordings, gzip when not set.
+	Codec encoding.Codec
+}
+
+// OverviewFormat returns overview format selected in settings.
+func (s Settings) OverviewFormat() encoding.OverviewFormat {
+	if s.CompactOverview {
+		return encoding.OverviewFormatFrames
+	}
+
+	return encoding.OverviewFormatGIF
+}
+
+// EncodeOptions returns options of encoding recording selected in settings.
+func (s Settings) EncodeOptions() []encoding.EncodeOption {
+	if s.Codec == 0 {
+		return nil
+	}
+
+	return []encoding.EncodeOption{encoding.WithCodec(s.Codec)}
+}
+
+// SettingsSource provides current recorder settings.
+type SettingsSource interface {
+	RecorderSettings() Settings
+}
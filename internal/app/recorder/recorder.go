@@ -1,25 +1,20 @@
 package recorder
 
 import (
-	"bytes"
 	"fmt"
-	"image"
-	"image/gif"
 	"log"
 	"os"
 	"path/filepath"
-	"runtime"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/lxn/walk"
 	"github.com/shivas/abyss-blackbox/internal/app/domain"
-	"github.com/shivas/abyss-blackbox/internal/config"
-	"github.com/shivas/abyss-blackbox/internal/fittings"
-	"github.com/shivas/abyss-blackbox/internal/overlay"
+	"github.com/shivas/abyss-blackbox/internal/app/events"
 	"github.com/shivas/abyss-blackbox/internal/version"
 	"github.com/shivas/abyss-blackbox/pkg/combatlog"
 	"github.com/shivas/abyss-blackbox/pkg/encoding"
+	"github.com/shivas/abyss-blackbox/pkg/inventory"
 )
 
 const (
@@ -28,70 +23,68 @@ const (
 	RecorderAwaitingInitialLoot
 )
 
+// maxAbyssTypeSnapshots is number of cargo snapshots of initial character abyss type detection is tried with,
+// filament might be consumed only after first snapshots were taken.
+const maxAbyssTypeSnapshots = 3
+
 type Recorder struct {
-	mutex               sync.Mutex
-	state               int
-	frameChan           chan *image.Paletted
-	loot                chan string
-	config              *config.CaptureConfig
-	done                chan bool
-	frames              []*image.Paletted
-	delays              []int
-	recordingName       string
-	lootRecords         []*encoding.LootRecord
-	notificationChannel chan domain.NotificationMessage
-	combatlogReader     *combatlog.Reader
-	charactersTracking  map[string]combatlog.CombatLogFile
-	weatherStrength     int
-	overlay             *overlay.Overlay
+	mutex              sync.Mutex
+	state              int
+	frames             FrameSource
+	clipboard          ClipboardSource
+	settingsSource     SettingsSource
+	settings           Settings
+	done               chan bool
+	frameCount         int
+	recordingName      string
+	journal            *encoding.JournalWriter
+	lastFrameAt        time.Time
+	events             Publisher
+	fittings           FittingsSource
+	combatlogReader    *combatlog.Reader
+	tailer             *combatlog.Tailer
+	tailerDone         chan struct{}
+	charactersTracking map[string]combatlog.CombatLogFile
+	weatherStrength    int
+	characters         []string
+	initialLoot        *encoding.LootRecord
+	abyssTypeDetected  bool
+	abyssTypeSnapshots int
+	ignoredClipboard   int
 }
 
 // NewRecorder constructs Recorder
-func NewRecorder(frameChan chan *image.Paletted, c *config.CaptureConfig, nc chan domain.NotificationMessage, clr *combatlog.Reader, ol *overlay.Overlay) *Recorder {
+func NewRecorder(frames FrameSource, clipboard ClipboardSource, settings SettingsSource, publisher Publisher, fittings FittingsSource, clr *combatlog.Reader) *Recorder {
 	return &Recorder{
-		frameChan:           frameChan,
-		loot:                make(chan string, 2),
-		state:               RecorderStopped,
-		config:              c,
-		frames:              make([]*image.Paletted, 0),
-		delays:              make([]int, 0),
-		done:                make(chan bool),
-		lootRecords:         make([]*encoding.LootRecord, 0),
-		notificationChannel: nc,
-		combatlogReader:     clr,
-		charactersTracking:  make(map[string]combatlog.CombatLogFile),
-		overlay:             ol,
-	}
-}
-
-// ClipboardListener event listener used to capture Clipboard changes for loot recording
-func (r *Recorder) ClipboardListener() {
-	runtime.LockOSThread()
-	defer runtime.UnlockOSThread()
-
-	clipboard, err := walk.Clipboard().Text()
-	if err != nil {
-		return
-	}
-	select {
-	case r.loot <- clipboard:
-	default:
-		log.Println("not recording, loot capture dropped")
+		frames:             frames,
+		clipboard:          clipboard,
+		state:              RecorderStopped,
+		settingsSource:     settings,
+		done:               make(chan bool),
+		events:             publisher,
+		fittings:           fittings,
+		combatlogReader:    clr,
+		charactersTracking: make(map[string]combatlog.CombatLogFile),
 	}
 }
 
 // GetWeatherStrengthListener returs listener that will set weather strength when invoked. In not running state it is NOOP.
 func (r *Recorder) GetWeatherStrengthListener(strength int) func() {
 	return func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
 		if r.state != RecorderRunning {
 			return
 		}
 
-		r.mutex.Lock()
-		defer r.mutex.Unlock()
 		r.weatherStrength = strength
-		r.notificationChannel <- domain.NotificationMessage{Title: "Abyssal.Space recorder", Message: fmt.Sprintf("Weather strength set to: %d%%", strength)}
-		r.overlay.ChangeProperty(overlay.Weather, fmt.Sprintf("Weather strength set to: %d%%", strength), &overlay.GreenColor)
+
+		if err := r.journal.AppendMetadata(&encoding.AbyssRecording{WeatherStrength: int32(strength)}); err != nil {
+			log.Printf("failed writing weather strength to journal: %v", err)
+		}
+
+		r.events.Publish(events.WeatherSet{Strength: strength})
 	}
 }
 
@@ -102,67 +95,182 @@ func (r *Recorder) StartLoop() {
 			select {
 			case <-r.done:
 				return // exit loop
+			case lootSnapshot := <-r.clipboard.Clipboard():
+				r.HandleClipboard(lootSnapshot)
+			case frame := <-r.frames.Frames():
+				r.HandleFrame(frame)
+			}
+		}
+	}(r)
+}
 
-			case lootSnapshot := <-r.loot:
-				r.mutex.Lock()
-
-				switch r.state {
-				case RecorderAwaitingInitialLoot:
-					r.notificationChannel <- domain.NotificationMessage{Title: "Abyssal.Space recording started...", Message: "Initial cargo received, awaiting cargo after fillament activation"}
-					r.overlay.ChangeProperty(overlay.TODO, "Activate fillament. Record loot after!", &overlay.RedColor)
-					r.overlay.ChangeProperty(overlay.Status, "Recording...", &overlay.GreenColor)
-					r.state = RecorderRunning
-					r.lootRecords = append(r.lootRecords, &encoding.LootRecord{Frame: 0, Loot: lootSnapshot})
-				case RecorderRunning:
-					r.notificationChannel <- domain.NotificationMessage{Title: "Abyssal.Space recorder", Message: "Loot captured from clipboard!"}
-					r.overlay.ChangeProperty(overlay.Status, "Recording...", &overlay.GreenColor)
-					r.overlay.ChangeProperty(overlay.TODO, "Loot captured from clipboard!", &overlay.YellowColor)
-
-					go func() {
-						time.Sleep(5 * time.Second)
-						r.overlay.ChangeProperty(overlay.TODO, "", nil)
-					}()
-
-					lr := &encoding.LootRecord{Frame: int32(len(r.frames) - 1), Loot: lootSnapshot}
-
-					log.Printf("loot appended: %v\n", lr)
-					r.lootRecords = append(r.lootRecords, lr)
-				default:
-					log.Printf("dropped loot record, %v", lootSnapshot)
-				}
+// HandleClipboard records clipboard snapshot as loot record.
+func (r *Recorder) HandleClipboard(lootSnapshot string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
 
-				r.mutex.Unlock()
+	if r.state != RecorderStopped && !r.settings.RecordAnyClipboard {
+		if _, err := inventory.Parse(lootSnapshot); err != nil {
+			r.ignoredClipboard++
+			r.events.Publish(events.ClipboardIgnored{Count: r.ignoredClipboard})
 
-			case frame := <-r.frameChan:
-				r.mutex.Lock()
-				if r.state == RecorderRunning { // append to buffer
-					r.frames = append(r.frames, frame)
-					r.delays = append(r.delays, 10)
+			return
+		}
+	}
 
-					if r.weatherStrength == 0 && (len(r.frames)%180 == 0) { // remind every 3 minutes skipping initial frame
-						r.notificationChannel <- domain.NotificationMessage{"Reminder", "Please record weather strength!"}
-						r.overlay.ChangeProperty(overlay.Weather, "Please record weather strength!", &overlay.SecondaryColor)
-					}
-				}
-				r.mutex.Unlock()
-			default:
-				time.Sleep(1 * time.Millisecond)
-			}
+	switch r.state {
+	case RecorderAwaitingInitialLoot:
+		r.state = RecorderRunning
+		lr, problem := r.lootRecord(0, lootSnapshot)
+
+		r.initialLoot = lr
+		r.appendLoot(lr)
+		r.events.Publish(events.InitialLootCaptured{Loot: lootSnapshot, Character: lr.Character})
+		r.warnAttribution(problem)
+	case RecorderRunning:
+		lr, problem := r.lootRecord(int32(r.frameCount-1), lootSnapshot)
+
+		r.appendLoot(lr)
+		r.events.Publish(events.LootCaptured{Frame: int(lr.Frame), Loot: lootSnapshot, Character: lr.Character})
+		r.warnAttribution(problem)
+
+		if !r.abyssTypeDetected && lr.Character == r.initialLoot.Character {
+			r.detectAbyssType(lootSnapshot)
 		}
-	}(r)
+	default:
+		r.events.Publish(events.LootDropped{Length: len(lootSnapshot)})
+	}
+}
+
+// lootRecord creates loot record of snapshot attributed to recorded character, problem is set when it could not be attributed.
+func (r *Recorder) lootRecord(frame int32, lootSnapshot string) (lr *encoding.LootRecord, problem string) {
+	character, problem := attributeLoot(lootSnapshot, r.characters, r.settings.LootRecordDiscriminator, r.settings.DiscriminatorQuantities)
+
+	return &encoding.LootRecord{Frame: frame, Loot: lootSnapshot, Timestamp: time.Now().UnixMilli(), Character: character}, problem
+}
+
+// warnAttribution publishes loot attribution problem, if any.
+func (r *Recorder) warnAttribution(problem string) {
+	if problem != "" {
+		r.events.Publish(events.LootAttributionWarning{Problem: problem})
+	}
+}
+
+// detectAbyssType detects abyss type from filament consumed between initial loot and loot snapshot, ship class
+// is taken from fittings of recorded characters. Detection is retried with following snapshots, at most
+// maxAbyssTypeSnapshots times. Detected type is written to recording unless manual override was chosen,
+// disagreement with override is only reported.
+func (r *Recorder) detectAbyssType(lootSnapshot string) {
+	before, err := inventory.Parse(r.initialLoot.GetLoot())
+	if err != nil {
+		r.abyssTypeDetected = true // nothing to compare with
+		return
+	}
+
+	after, err := inventory.Parse(lootSnapshot)
+	if err != nil {
+		return // not a cargo copy, wait for next snapshot
+	}
+
+	abyssType, ok := inventory.DetectAbyssType(inventory.Diff(before, after))
+	if !ok {
+		r.abyssTypeSnapshots++
+		r.abyssTypeDetected = r.abyssTypeSnapshots >= maxAbyssTypeSnapshots
+
+		return
+	}
+
+	r.abyssTypeDetected = true
+	abyssType.ShipType = r.fleetShipType()
+
+	detected := events.AbyssTypeDetected{
+		Filament:    abyssType.Filament,
+		ShipType:    abyssType.ShipType,
+		Tier:        abyssType.Tier,
+		Weather:     abyssType.Weather,
+		Description: abyssType.String(),
+	}
+
+	metadata := &encoding.AbyssRecording{DetectedFilament: abyssType.Filament}
+
+	if r.settings.AbyssTypeOverride {
+		shipType := encoding.AbyssRecording_AbyssShipType(r.settings.AbyssShipType)
+		detected.Override = inventory.AbyssType{Tier: r.settings.AbyssTier, Weather: r.settings.AbyssWeather, ShipType: shipType}.String()
+		detected.Conflict = !abyssType.Matches(shipType, r.settings.AbyssTier, r.settings.AbyssWeather)
+	} else {
+		metadata.AbyssShipType = abyssType.ShipType
+		metadata.AbyssTier = int32(abyssType.Tier)
+		metadata.AbyssWheather = abyssType.Weather
+	}
+
+	if err = r.journal.AppendMetadata(metadata); err != nil {
+		log.Printf("failed writing detected abyss type to journal: %v", err)
+	}
+
+	r.events.Publish(detected)
+}
+
+// fleetShipType returns ship class of ships fitted for recorded characters, UNKNOWN_SHIP when no fitted ship
+// has known class or fitted ships are of different classes.
+func (r *Recorder) fleetShipType() encoding.AbyssRecording_AbyssShipType {
+	shipType := encoding.AbyssRecording_UNKNOWN_SHIP
+
+	for _, character := range r.characters {
+		class := inventory.ShipClass(r.fittings.ShipGroupForPilot(character))
+
+		switch {
+		case class == encoding.AbyssRecording_UNKNOWN_SHIP:
+		case shipType == encoding.AbyssRecording_UNKNOWN_SHIP:
+			shipType = class
+		case shipType != class:
+			return encoding.AbyssRecording_UNKNOWN_SHIP
+		}
+	}
+
+	return shipType
+}
+
+// HandleFrame appends overview frame to running recording.
+func (r *Recorder) HandleFrame(frame domain.Frame) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.state != RecorderRunning {
+		return
+	}
+
+	// frames missing between two captured frames (capture loop stalled or channel was full)
+	if !r.lastFrameAt.IsZero() {
+		if missing := int((frame.CapturedAt.Sub(r.lastFrameAt)+encoding.FrameInterval/2)/encoding.FrameInterval) - 1; missing > 0 {
+			r.events.Publish(events.FrameDropped{Count: missing, At: frame.CapturedAt})
+		}
+	}
+
+	if err := r.journal.AppendFrame(frame.Image, frame.CapturedAt); err != nil {
+		r.events.Publish(events.FrameDropped{Count: 1, At: frame.CapturedAt, Err: err})
+	} else {
+		r.frameCount++
+		r.lastFrameAt = frame.CapturedAt
+	}
+
+	if r.weatherStrength == 0 && r.frameCount > 0 && (r.frameCount%180 == 0) { // remind every 3 minutes skipping initial frame
+		r.events.Publish(events.WeatherReminder{Frames: r.frameCount})
+	}
 }
 
 // Start recording of abyssal run
-func (r *Recorder) Start(characters []string) {
+func (r *Recorder) Start(characters []string) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	r.settings = r.settingsSource.RecorderSettings()
+
 	// make sure recordings folder exists
-	_, err := os.Stat(r.config.Recordings)
+	_, err := os.Stat(r.settings.Recordings)
 	if os.IsNotExist(err) {
-		err = os.MkdirAll(r.config.Recordings, os.ModeDir)
+		err = os.MkdirAll(r.settings.Recordings, os.ModeDir)
 		if err != nil {
-			log.Fatalf("could not create folder: %s", r.config.Recordings) //nolint:gocritic // it's dead jim
+			return fmt.Errorf("could not create folder: %s", r.settings.Recordings)
 		}
 	}
 
@@ -189,92 +297,241 @@ func (r *Recorder) Start(characters []string) {
 
 	r.combatlogReader.MarkStartOffsets(r.charactersTracking)
 
-	r.recordingName = filepath.Join(r.config.Recordings, fmt.Sprintf("%s.abyss", time.Now().Format("2006-Jan-2-15-04-05")))
-	r.frames = make([]*image.Paletted, 0)
-	r.delays = make([]int, 0)
-	r.lootRecords = make([]*encoding.LootRecord, 0)
+	r.recordingName = filepath.Join(r.settings.Recordings, fmt.Sprintf("%s.abyss", time.Now().Format("2006-Jan-2-15-04-05")))
+
+	metadata := &encoding.AbyssRecording{
+		TestServer:              r.settings.TestServer,
+		LootRecordDiscriminator: r.settings.LootRecordDiscriminator,
+		RecorderVersion:         version.RecorderVersion,
+		ManualAbyssTypeOverride: r.settings.AbyssTypeOverride,
+		StartedAt:               time.Now().UnixMilli(),
+	}
+
+	if r.settings.AbyssTypeOverride {
+		metadata.AbyssShipType = encoding.AbyssRecording_AbyssShipType(r.settings.AbyssShipType)
+		metadata.AbyssTier = int32(r.settings.AbyssTier)
+		metadata.AbyssWheather = r.settings.AbyssWeather
+	}
+
+	r.journal, err = encoding.CreateJournal(r.recordingName+encoding.JournalExtension, metadata)
+	if err != nil {
+		return fmt.Errorf("failed creating recording journal: %w", err)
+	}
+
+	for character, offset := range r.combatlogReader.StartOffsets() {
+		logfile := r.charactersTracking[character]
+
+		err = r.journal.AppendCombatLogOffset(&encoding.CombatLogOffset{
+			CharacterName: character,
+			Filename:      logfile.Filename,
+			Offset:        offset,
+			LanguageCode:  logfile.LanguageCode,
+		})
+		if err != nil {
+			log.Printf("failed writing combat log offset to journal: %v", err)
+		}
+	}
+
+	r.tailer = combatlog.NewTailer(r.combatlogReader, r.charactersTracking, r.combatlogReader.StartOffsets(), combatlog.DefaultTailInterval)
+	r.tailerDone = make(chan struct{})
+	r.tailer.Start()
+
+	go followCombatLog(r.journal, r.tailer.Lines(), r.tailerDone)
+
+	r.frameCount = 0
+	r.lastFrameAt = time.Time{}
 	r.weatherStrength = 0
+	r.characters = characters
+	r.initialLoot = nil
+	r.abyssTypeDetected = false
+	r.abyssTypeSnapshots = 0
+	r.ignoredClipboard = 0
 	r.state = RecorderAwaitingInitialLoot
-	r.notificationChannel <- domain.NotificationMessage{Title: "Recording starting...", Message: "CTRL+A, CTRL+C your inventory"}
-	r.overlay.ChangeProperty(overlay.Status, "Recording starting", &overlay.CyanColor)
-	r.overlay.ChangeProperty(overlay.Weather, "TODO: Record weather strength", nil)
-	r.overlay.ChangeProperty(overlay.TODO, "TODO: CTRL+A, CTRL+C your inventory", nil)
+	r.events.Publish(events.RecordingStarted{Filename: r.recordingName, Characters: characters})
+
+	for _, problem := range discriminatorProblems(characters, r.settings.LootRecordDiscriminator, r.settings.DiscriminatorQuantities) {
+		r.warnAttribution(problem)
+	}
+
+	return nil
 }
 
 // Stop stops recording and writes .abyss file if frames captured
-func (r *Recorder) Stop(fm *fittings.FittingsManager) (string, error) {
+func (r *Recorder) Stop() (string, error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if len(r.frames) == 0 {
-		r.state = RecorderStopped
-		return r.recordingName, fmt.Errorf("there was no frames captured, skipping recording of abyss run")
+	r.state = RecorderStopped
+
+	if err := r.writeRecording(); err != nil {
+		r.events.Publish(events.RecordingFailed{Filename: r.recordingName, Err: err})
+		return r.recordingName, err
+	}
+
+	r.events.Publish(events.RecordingSaved{Filename: r.recordingName, Frames: r.frameCount})
+
+	return r.recordingName, nil
+}
+
+// writeRecording writes journal of stopped recording out to .abyss file.
+func (r *Recorder) writeRecording() error {
+	journalName := r.journal.Filename()
+
+	remaining := r.tailer.Stop()
+	<-r.tailerDone
+	appendCombatLogLines(r.journal, remaining)
+
+	if r.frameCount == 0 {
+		_ = r.journal.Close()
+		_ = os.Remove(journalName)
+
+		return fmt.Errorf("there was no frames captured, skipping recording of abyss run")
 	}
 
 	runFittings := make(map[string]*encoding.Fit, len(r.charactersTracking))
 
 	for char := range r.charactersTracking {
-		fit := fm.GetFittingForPilot(char)
-		if fit != nil {
-			runFittings[char] = &encoding.Fit{
-				Source:      fit.Source,
-				ForeignID:   fit.ForeignID,
-				FittingName: fit.FittingName,
-				EFT:         fit.EFT,
-				FFH:         fit.FFH,
-				Price:       fit.Price,
-				ShipName:    fit.ShipName,
-				ShipTypeID:  fit.ShipTypeID,
-			}
+		if fit := r.fittings.FittingForPilot(char); fit != nil {
+			runFittings[char] = fit
 		}
 	}
 
-	file, _ := os.Create(r.recordingName)
-	defer file.Close()
+	metadata := &encoding.AbyssRecording{Fittings: runFittings, StoppedAt: time.Now().UnixMilli(), IgnoredClipboard: int32(r.ignoredClipboard)}
 
-	defer log.Printf("Recording %d frames, written to file: %s", len(r.frames), r.recordingName)
+	if err := r.journal.AppendMetadata(metadata); err != nil {
+		log.Printf("failed writing fittings to journal: %v", err)
+	}
 
-	var buf bytes.Buffer
+	if err := r.journal.Close(); err != nil {
+		return err
+	}
 
-	r.state = RecorderStopped
-	anim := gif.GIF{Delay: r.delays, LoopCount: -1, Image: r.frames}
+	return finalizeJournal(journalName, r.recordingName, r.settings)
+}
+
+// UnfinishedJournals returns journals left in recordings folder by runs that were never stopped (crash, power loss).
+func (r *Recorder) UnfinishedJournals() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
 
-	err := gif.EncodeAll(&buf, &anim)
+	journals, err := filepath.Glob(filepath.Join(r.settingsSource.RecorderSettings().Recordings, "*.abyss"+encoding.JournalExtension))
 	if err != nil {
-		return r.recordingName, err
+		return nil
 	}
 
-	defer func() {
-		r.notificationChannel <- domain.NotificationMessage{Title: "Abyss recorder", Message: fmt.Sprintf("Abyss run successfully recorded to file: %s", r.recordingName)}
-		r.overlay.ChangeProperty(overlay.TODO, "Abyss run successfully recorded to file", &overlay.GreenColor)
-		r.overlay.ChangeProperty(overlay.Status, "Recorder on standby", &overlay.YellowColor)
-	}()
-
-	defer func() {
-		// let GC collect memory allocated for recording
-		r.frames = []*image.Paletted{}
-		r.lootRecords = []*encoding.LootRecord{}
-	}()
-
-	abyssFile := encoding.AbyssRecording{
-		Overview:                buf.Bytes(),
-		Loot:                    r.lootRecords,
-		CombatLog:               r.combatlogReader.GetCombatLogRecords(r.charactersTracking),
-		TestServer:              r.config.TestServer,
-		WeatherStrength:         int32(r.weatherStrength),
-		LootRecordDiscriminator: r.config.LootRecordDiscriminator,
-		RecorderVersion:         version.RecorderVersion,
-		ManualAbyssTypeOverride: r.config.AbyssTypeOverride,
-		Fittings:                runFittings,
+	result := make([]string, 0, len(journals))
+
+	for _, j := range journals {
+		if r.state != RecorderStopped && r.journal != nil && j == r.journal.Filename() {
+			continue // currently recording
+		}
+
+		result = append(result, j)
+	}
+
+	return result
+}
+
+// RecoverJournal converts unfinished journal into .abyss recording, returning recording filename.
+func (r *Recorder) RecoverJournal(journalName string) (string, error) {
+	recordingName := strings.TrimSuffix(journalName, encoding.JournalExtension)
+
+	return recordingName, finalizeJournal(journalName, recordingName, r.settingsSource.RecorderSettings())
+}
+
+// finalizeJournal writes recording out of journal and removes journal afterwards.
+// Combat log of characters missing in journal is read again from game logs using offsets stored in journal.
+func finalizeJournal(journalName, recordingName string, settings Settings) error {
+	journal, err := encoding.ReadJournalFile(journalName)
+	if err != nil {
+		return err
+	}
+
+	for _, errr := range journal.ReadMissingCombatLogs() {
+		log.Printf("failed reading combat log: %v", errr)
+	}
+
+	abyssFile, err := journal.Finalize(settings.OverviewFormat())
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(recordingName)
+	if err != nil {
+		return err
+	}
+
+	if err = abyssFile.Encode(file, settings.EncodeOptions()...); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(journalName)
+}
+
+// followCombatLog appends combat log lines to journal as tailer delivers them, lines available at once are written as single entry.
+func followCombatLog(journal *encoding.JournalWriter, lines <-chan combatlog.Line, done chan<- struct{}) {
+	defer close(done)
+
+	for line := range lines {
+		batch := []combatlog.Line{line}
+
+	drain:
+		for {
+			select {
+			case l, ok := <-lines:
+				if !ok {
+					break drain
+				}
+
+				batch = append(batch, l)
+			default:
+				break drain
+			}
+		}
+
+		appendCombatLogLines(journal, batch)
+	}
+}
+
+// appendCombatLogLines writes lines to journal, one combat log record per character.
+func appendCombatLogLines(journal *encoding.JournalWriter, lines []combatlog.Line) {
+	records := make([]*combatlog.CombatLogRecord, 0, 1)
+
+	for _, line := range lines {
+		var record *combatlog.CombatLogRecord
+
+		for _, clr := range records {
+			if clr.CharacterName == line.Character {
+				record = clr
+				break
+			}
+		}
+
+		if record == nil {
+			record = &combatlog.CombatLogRecord{CharacterName: line.Character, LanguageCode: line.LanguageCode}
+			records = append(records, record)
+		}
+
+		record.CombatLogLines = append(record.CombatLogLines, line.Text)
 	}
 
-	if r.config.AbyssTypeOverride {
-		abyssFile.AbyssShipType = encoding.AbyssRecording_AbyssShipType(r.config.AbyssShipType)
-		abyssFile.AbyssTier = int32(r.config.AbyssTier)
-		abyssFile.AbyssWheather = r.config.AbyssWeather
+	for _, clr := range records {
+		if err := journal.AppendCombatLog(clr); err != nil {
+			log.Printf("failed writing combat log to journal: %v", err)
+		}
 	}
+}
 
-	return r.recordingName, abyssFile.Encode(file)
+// appendLoot writes loot record to journal.
+func (r *Recorder) appendLoot(lr *encoding.LootRecord) {
+	if err := r.journal.AppendLoot(lr); err != nil {
+		log.Printf("failed writing loot to journal: %v", err)
+	}
 }
 
 // StopLoop stops main recording loop
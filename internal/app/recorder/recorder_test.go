@@ -0,0 +1,338 @@
+package recorder
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/internal/app/domain"
+	"github.com/shivas/abyss-blackbox/internal/app/events"
+	"github.com/shivas/abyss-blackbox/pkg/combatlog"
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+type fakeSources struct {
+	frames    chan domain.Frame
+	clipboard chan string
+}
+
+func (f *fakeSources) Frames() <-chan domain.Frame { return f.frames }
+func (f *fakeSources) Clipboard() <-chan string    { return f.clipboard }
+
+type eventLog struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (l *eventLog) Publish(e events.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, e)
+}
+
+type fakeFittings map[string]*encoding.Fit
+
+func (f fakeFittings) FittingForPilot(character string) *encoding.Fit { return f[character] }
+
+// shipGroups are inventory groups of ships used in tests.
+var shipGroups = map[int32]int32{52250: 25, 17715: 26} // Nergal frigate, Gila cruiser
+
+func (f fakeFittings) ShipGroupForPilot(character string) int32 {
+	return shipGroups[f[character].GetShipTypeID()]
+}
+
+func (r *Recorder) capturedFrames() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.frameCount
+}
+
+type fakeSettings Settings
+
+func (f fakeSettings) RecorderSettings() Settings { return Settings(f) }
+
+const gamelogHeader = `------------------------------------------------------------
+  Gamelog
+  Listener: Runner1
+  Session Started: 2020.10.22 20:59:44
+------------------------------------------------------------
+[ 2020.10.22 20:59:46 ] (hint) Attempting to join a channel
+`
+
+func newTestRecorder(t *testing.T) (*Recorder, *fakeSources, *eventLog, string, string) {
+	t.Helper()
+
+	logsDir := t.TempDir()
+	recordingsDir := t.TempDir()
+
+	gamelog := filepath.Join(logsDir, time.Now().UTC().Format("20060102_150405")+".txt")
+	if err := os.WriteFile(gamelog, []byte(gamelogHeader), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sources := &fakeSources{frames: make(chan domain.Frame), clipboard: make(chan string)}
+	published := &eventLog{}
+	settings := fakeSettings{Recordings: recordingsDir, TestServer: true, LootRecordDiscriminator: "Quafe"}
+	fits := fakeFittings{"Runner1": {FittingName: "FFA", ShipName: "Nergal", ShipTypeID: 52250}}
+
+	r := NewRecorder(sources, sources, settings, published, fits, combatlog.NewReader(logsDir))
+
+	return r, sources, published, gamelog, recordingsDir
+}
+
+func TestRecorder_FullRun(t *testing.T) {
+	r, sources, published, gamelog, recordingsDir := newTestRecorder(t)
+
+	if err := r.Start([]string{"Runner1"}); err != nil {
+		t.Fatalf("Recorder.Start() error = %v", err)
+	}
+
+	if r.Status() != RecorderAwaitingInitialLoot {
+		t.Fatalf("Recorder.Status() = %d, want awaiting initial loot", r.Status())
+	}
+
+	r.StartLoop()
+
+	palette := []color.Color{color.White, color.Black}
+	started := time.Date(2020, 10, 22, 21, 0, 0, 0, time.UTC)
+	frame := func(offset time.Duration) domain.Frame {
+		return domain.Frame{Image: image.NewPaletted(image.Rect(0, 0, 10, 10), palette), CapturedAt: started.Add(offset)}
+	}
+
+	sources.frames <- frame(0)     // dropped, initial loot not captured yet
+	sources.clipboard <- "hunter2" // ignored, not inventory copy
+	sources.clipboard <- "Calm Dark Filament\t1\nQuafe\t1\n"
+
+	combatLines := []string{
+		"[ 2020.10.22 21:00:57 ] (combat) <color=0xff00ffff><b>180</b> <color=0x77ffffff><font size=10>to</font> <b><color=0xffffffff>Gistii Rogue</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Penetrates",
+		"[ 2020.10.22 21:01:28 ] (combat) Gistii Ambusher misses you completely",
+	}
+
+	f, err := os.OpenFile(gamelog, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, l := range combatLines {
+		_, _ = f.WriteString(l + "\n")
+	}
+
+	f.Close()
+
+	sources.frames <- frame(time.Second)
+	sources.frames <- frame(2 * time.Second)
+	sources.frames <- frame(4 * time.Second) // one frame missing
+
+	// weather strength is set outside of recording loop, wait until loop handled frames above
+	for deadline := time.Now().Add(time.Second); r.capturedFrames() < 3 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+
+	r.GetWeatherStrengthListener(50)()
+
+	sources.clipboard <- "https://abyssal.space" // ignored, not inventory copy
+
+	sources.clipboard <- "Quafe\t1\nTriglavian Survey Database\t12\n"
+
+	r.StopLoop() // returns once loop handled all events above
+
+	filename, err := r.Stop()
+	if err != nil {
+		t.Fatalf("Recorder.Stop() error = %v", err)
+	}
+
+	if filepath.Dir(filename) != recordingsDir {
+		t.Errorf("recording written to %s, want folder %s", filename, recordingsDir)
+	}
+
+	if _, err = os.Stat(filename + encoding.JournalExtension); !os.IsNotExist(err) {
+		t.Errorf("journal was not removed after stop: %v", err)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	rec, err := encoding.Decode(file)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if frames, errr := rec.FrameCount(); errr != nil || frames != 3 {
+		t.Errorf("recorded frames = %d, %v, want 3", frames, errr)
+	}
+
+	if at, ok := rec.FrameTime(2); !ok || !at.Equal(started.Add(4*time.Second)) {
+		t.Errorf("AbyssRecording.FrameTime(2) = %s, %t", at, ok)
+	}
+
+	if len(rec.GetLoot()) != 2 || rec.GetLoot()[0].GetFrame() != 0 || rec.GetLoot()[1].GetFrame() != 2 || rec.GetLoot()[1].GetCharacter() != "Runner1" {
+		t.Errorf("recorded loot = %v", rec.GetLoot())
+	}
+
+	if !rec.GetTestServer() || rec.GetWeatherStrength() != 50 || rec.GetLootRecordDiscriminator() != "Quafe" {
+		t.Errorf("recorded metadata = test server %t, weather %d, discriminator %q", rec.GetTestServer(), rec.GetWeatherStrength(), rec.GetLootRecordDiscriminator())
+	}
+
+	if rec.GetManualAbyssTypeOverride() || rec.GetAbyssShipType() != encoding.AbyssRecording_FRIGATE || rec.GetAbyssTier() != 1 ||
+		rec.GetAbyssWheather() != "Dark" || rec.GetDetectedFilament() != "Calm Dark Filament" {
+		t.Errorf("recorded abyss type = %s T%d %s from %q", rec.GetAbyssShipType(), rec.GetAbyssTier(), rec.GetAbyssWheather(), rec.GetDetectedFilament())
+	}
+
+	if rec.GetIgnoredClipboard() != 2 {
+		t.Errorf("recorded ignored clipboard changes = %d, want 2", rec.GetIgnoredClipboard())
+	}
+
+	if rec.GetStartedAt() == 0 || rec.GetStoppedAt() < rec.GetStartedAt() {
+		t.Errorf("recorded start = %d, stop = %d", rec.GetStartedAt(), rec.GetStoppedAt())
+	}
+
+	if rec.GetFittings()["Runner1"].GetShipName() != "Nergal" {
+		t.Errorf("recorded fittings = %v", rec.GetFittings())
+	}
+
+	if len(rec.GetCombatLog()) != 1 || len(rec.GetCombatLog()[0].GetCombatLogLines()) != len(combatLines) {
+		t.Fatalf("recorded combat log = %v", rec.GetCombatLog())
+	}
+
+	for i, l := range rec.GetCombatLog()[0].GetCombatLogLines() {
+		if l != combatLines[i] {
+			t.Errorf("combat log line %d = %q, want %q", i, l, combatLines[i])
+		}
+	}
+
+	published.mu.Lock()
+	defer published.mu.Unlock()
+
+	want := []events.Event{
+		events.RecordingStarted{Filename: filename, Characters: []string{"Runner1"}},
+		events.ClipboardIgnored{Count: 1},
+		events.InitialLootCaptured{Loot: "Calm Dark Filament\t1\nQuafe\t1\n", Character: "Runner1"},
+		events.FrameDropped{Count: 1, At: started.Add(4 * time.Second)},
+		events.WeatherSet{Strength: 50},
+		events.ClipboardIgnored{Count: 2},
+		events.LootCaptured{Frame: 2, Loot: "Quafe\t1\nTriglavian Survey Database\t12\n", Character: "Runner1"},
+		events.AbyssTypeDetected{
+			Filament: "Calm Dark Filament", ShipType: encoding.AbyssRecording_FRIGATE, Tier: 1, Weather: "Dark", Description: "Frigates T1 Dark",
+		},
+		events.RecordingSaved{Filename: filename, Frames: 3},
+	}
+
+	if !reflect.DeepEqual(published.events, want) {
+		t.Errorf("published events = %+v, want %+v", published.events, want)
+	}
+}
+
+func TestRecorder_DetectAbyssType(t *testing.T) {
+	const (
+		initial  = "Calm Dark Filament\t1\nQuafe\t1\n"
+		unused   = "Calm Dark Filament\t1\nQuafe\t1\nTriglavian Survey Database\t1\n"
+		consumed = "Quafe\t1\n"
+	)
+
+	tests := []struct {
+		name      string
+		fits      fakeFittings
+		snapshots []string
+		want      *events.AbyssTypeDetected
+	}{
+		{
+			name:      "detected after filament consumed",
+			fits:      fakeFittings{"Runner1": {ShipTypeID: 17715}},
+			snapshots: []string{unused, consumed},
+			want:      &events.AbyssTypeDetected{Filament: "Calm Dark Filament", ShipType: encoding.AbyssRecording_CRUISER, Tier: 1, Weather: "Dark", Description: "Cruiser T1 Dark"},
+		},
+		{
+			name:      "ship class unknown without fitting",
+			fits:      fakeFittings{},
+			snapshots: []string{consumed},
+			want:      &events.AbyssTypeDetected{Filament: "Calm Dark Filament", Tier: 1, Weather: "Dark", Description: "T1 Dark"},
+		},
+		{
+			name:      "given up after snapshots without filament consumed",
+			fits:      fakeFittings{},
+			snapshots: []string{unused, unused, unused, consumed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _, published, _, _ := newTestRecorder(t)
+			r.fittings = tt.fits
+
+			if err := r.Start([]string{"Runner1"}); err != nil {
+				t.Fatalf("Recorder.Start() error = %v", err)
+			}
+
+			r.HandleClipboard(initial)
+
+			for _, snapshot := range tt.snapshots {
+				r.HandleClipboard(snapshot)
+			}
+
+			_, _ = r.Stop() // no frames recorded
+
+			var got *events.AbyssTypeDetected
+
+			for _, e := range published.events {
+				if detected, ok := e.(events.AbyssTypeDetected); ok {
+					got = &detected
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("published AbyssTypeDetected = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecorder_LootDroppedWhileStopped(t *testing.T) {
+	r, _, published, _, _ := newTestRecorder(t)
+
+	r.HandleClipboard("hunter2")
+
+	// clipboard text might be anything, only its length is published
+	if want := []events.Event{events.LootDropped{Length: 7}}; !reflect.DeepEqual(published.events, want) {
+		t.Errorf("published events = %+v, want %+v", published.events, want)
+	}
+}
+
+func TestRecorder_StopWithoutFrames(t *testing.T) {
+	r, _, published, _, recordingsDir := newTestRecorder(t)
+
+	if err := r.Start([]string{"Runner1"}); err != nil {
+		t.Fatalf("Recorder.Start() error = %v", err)
+	}
+
+	r.HandleClipboard("Quafe\t1\n")
+
+	if _, err := r.Stop(); err == nil {
+		t.Errorf("Recorder.Stop() without frames expected error")
+	}
+
+	if last := published.events[len(published.events)-1]; reflect.TypeOf(last) != reflect.TypeOf(events.RecordingFailed{}) {
+		t.Errorf("last published event = %T, want events.RecordingFailed", last)
+	}
+
+	if r.Status() != RecorderStopped {
+		t.Errorf("Recorder.Status() = %d, want stopped", r.Status())
+	}
+
+	if files, _ := filepath.Glob(filepath.Join(recordingsDir, "*")); len(files) != 0 {
+		t.Errorf("recordings folder not empty: %v", files)
+	}
+
+	if journals := r.UnfinishedJournals(); len(journals) != 0 {
+		t.Errorf("Recorder.UnfinishedJournals() = %v", journals)
+	}
+}
@@ -0,0 +1,302 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/internal/app/domain"
+	"github.com/shivas/abyss-blackbox/internal/app/events"
+	"github.com/shivas/abyss-blackbox/internal/app/recorder"
+	"github.com/shivas/abyss-blackbox/internal/index"
+	"github.com/shivas/abyss-blackbox/internal/overlay"
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+	"github.com/shivas/abyss-blackbox/pkg/inventory"
+	"github.com/shivas/abyss-blackbox/pkg/prices"
+)
+
+// notificationSubscriber shows recorder events as tray notifications.
+func notificationSubscriber(nc chan<- domain.NotificationMessage) func(events.Event) {
+	return func(e events.Event) {
+		var title, message string
+
+		switch e := e.(type) {
+		case events.RecordingStarted:
+			title, message = "Recording starting...", "CTRL+A, CTRL+C your inventory"
+		case events.InitialLootCaptured:
+			title, message = "Abyssal.Space recording started...", "Initial cargo received, awaiting cargo after fillament activation"
+		case events.LootCaptured:
+			title, message = "Abyssal.Space recorder", "Loot captured from clipboard!"
+		case events.LootAttributionWarning:
+			title, message = "Loot record discriminator", e.Problem
+		case events.AbyssTypeDetected:
+			if !e.Conflict {
+				return // shown in overlay
+			}
+
+			title, message = "Abyss type mismatch", fmt.Sprintf("Consumed %s (%s) but override is set to %s, override is kept", e.Filament, e.Description, e.Override)
+		case events.WeatherSet:
+			title, message = "Abyssal.Space recorder", fmt.Sprintf("Weather strength set to: %d%%", e.Strength)
+		case events.WeatherReminder:
+			title, message = "Reminder", "Please record weather strength!"
+		case events.RecordingSaved:
+			title, message = "Abyss recorder", fmt.Sprintf("Abyss run successfully recorded to file: %s", e.Filename)
+		case events.UploadFinished:
+			switch {
+			case e.Bulk:
+				return // reported by bulk upload progress
+			case e.Err != nil:
+				title, message = "Record uploading error", e.Err.Error()
+			default:
+				title, message = "Record uploaded successfully", e.Result
+			}
+		case events.BulkUploadProgress:
+			if e.Done < e.Total {
+				return
+			}
+
+			title, message = "Abyssal.Space recorder", fmt.Sprintf("Uploaded %d recording(s), %d failed", e.Done-e.Failed, e.Failed)
+		default:
+			return
+		}
+
+		nc <- domain.NotificationMessage{Title: title, Message: message}
+	}
+}
+
+// overlaySubscriber shows recorder status in overlay.
+func overlaySubscriber(o *overlay.Overlay) func(events.Event) {
+	return func(e events.Event) {
+		switch e := e.(type) {
+		case events.RecordingStarted:
+			o.ChangeProperty(overlay.Status, "Recording starting", &overlay.CyanColor)
+			o.ChangeProperty(overlay.Weather, "TODO: Record weather strength", nil)
+			o.ChangeProperty(overlay.TODO, "TODO: CTRL+A, CTRL+C your inventory", nil)
+		case events.InitialLootCaptured:
+			o.ChangeProperty(overlay.TODO, "Activate fillament. Record loot after!", &overlay.RedColor)
+			o.ChangeProperty(overlay.Status, "Recording...", &overlay.GreenColor)
+		case events.LootCaptured:
+			o.ChangeProperty(overlay.Status, "Recording...", &overlay.GreenColor)
+			o.ChangeProperty(overlay.TODO, "Loot captured from clipboard!", &overlay.YellowColor)
+
+			time.AfterFunc(5*time.Second, func() {
+				o.ChangeProperty(overlay.TODO, "", nil)
+			})
+		case events.LootAttributionWarning:
+			o.ChangeProperty(overlay.TODO, e.Problem, &overlay.RedColor)
+		case events.AbyssTypeDetected:
+			switch {
+			case e.Conflict:
+				o.ChangeProperty(overlay.Override, fmt.Sprintf("Abyss type override: %s, detected: %s!", e.Override, e.Description), &overlay.RedColor)
+			case e.Override != "":
+				o.ChangeProperty(overlay.Override, fmt.Sprintf("Abyss type override: %s (confirmed)", e.Override), &overlay.SecondaryColor)
+			default:
+				o.ChangeProperty(overlay.Override, fmt.Sprintf("Abyss type detected: %s", e.Description), &overlay.GreenColor)
+			}
+		case events.WeatherSet:
+			o.ChangeProperty(overlay.Weather, fmt.Sprintf("Weather strength set to: %d%%", e.Strength), &overlay.GreenColor)
+		case events.WeatherReminder:
+			o.ChangeProperty(overlay.Weather, "Please record weather strength!", &overlay.SecondaryColor)
+		case events.RecordingSaved:
+			o.ChangeProperty(overlay.TODO, "Abyss run successfully recorded to file", &overlay.GreenColor)
+			o.ChangeProperty(overlay.Status, "Recorder on standby", &overlay.YellowColor)
+			o.ChangeProperty(overlay.Weather, "", nil)
+		case events.RecordingFailed:
+			o.ChangeProperty(overlay.Status, "Recorder on standby", &overlay.YellowColor)
+			o.ChangeProperty(overlay.Weather, "", nil)
+		case events.UploadFinished:
+			switch {
+			case e.Bulk:
+			case e.Err != nil:
+				o.ChangeProperty(overlay.TODO, "Record upload failed", &overlay.RedColor)
+			default:
+				o.ChangeProperty(overlay.TODO, "Record uploaded successfully", &overlay.GreenColor)
+			}
+		case events.BulkUploadProgress:
+			if e.Done < e.Total {
+				o.ChangeProperty(overlay.TODO, fmt.Sprintf("Uploading recordings: %d/%d", e.Done, e.Total), &overlay.CyanColor)
+				return
+			}
+
+			color := &overlay.GreenColor
+			if e.Failed > 0 {
+				color = &overlay.RedColor
+			}
+
+			o.ChangeProperty(overlay.TODO, fmt.Sprintf("Uploaded %d recording(s), %d failed", e.Done-e.Failed, e.Failed), color)
+		}
+	}
+}
+
+// logSubscriber writes recorder events to application log.
+func logSubscriber(e events.Event) {
+	switch e := e.(type) {
+	case events.RecordingStarted:
+		log.Printf("recording started: %s, characters: %v", e.Filename, e.Characters)
+	case events.InitialLootCaptured:
+		log.Printf("initial loot of %q captured: %s", e.Character, lootSummary(e.Loot))
+	case events.LootCaptured:
+		log.Printf("loot of %q appended at frame %d: %s", e.Character, e.Frame, lootSummary(e.Loot))
+	case events.LootAttributionWarning:
+		log.Printf("loot attribution: %s", e.Problem)
+	case events.ClipboardIgnored:
+		log.Printf("clipboard text is not inventory copy, not recorded (%d ignored so far)", e.Count)
+	case events.LootDropped:
+		log.Printf("dropped loot record of %d characters, recorder is not running", e.Length)
+	case events.AbyssTypeDetected:
+		if e.Conflict {
+			log.Printf("consumed %s detected as %s, disagrees with override %s", e.Filament, e.Description, e.Override)
+		} else {
+			log.Printf("consumed %s detected as %s", e.Filament, e.Description)
+		}
+	case events.WeatherSet:
+		log.Printf("weather strength set to: %d%%", e.Strength)
+	case events.FrameDropped:
+		if e.Err != nil {
+			log.Printf("failed writing frame to journal: %v", e.Err)
+		} else {
+			log.Printf("%d frame(s) dropped before %s", e.Count, e.At.Format(time.TimeOnly))
+		}
+	case events.RecordingSaved:
+		log.Printf("Recording %d frames, written to file: %s", e.Frames, e.Filename)
+	case events.RecordingFailed:
+		log.Printf("recording %s failed: %v", e.Filename, e.Err)
+	case events.UploadQueued:
+		log.Printf("queued upload of %s", e.Filename)
+	case events.UploadRetrying:
+		log.Printf("upload attempt %d of %s failed, retrying at %s: %v", e.Attempts, e.Filename, e.NextAttempt.Format(time.DateTime), e.Err)
+	case events.BulkUploadProgress:
+		log.Printf("bulk upload: %d/%d done, %d failed", e.Done, e.Total, e.Failed)
+	case events.UploadFinished:
+		if e.Err != nil {
+			log.Printf("upload of %s failed: %v", e.Filename, e.Err)
+		} else {
+			log.Printf("uploaded %s: %s", e.Filename, e.Result)
+		}
+	}
+}
+
+// lootSummary describes loot snapshot for log without its content, which is whatever was in clipboard.
+func lootSummary(loot string) string {
+	inv, err := inventory.Parse(loot)
+	if err != nil {
+		return fmt.Sprintf("%d characters of text", len(loot))
+	}
+
+	return fmt.Sprintf("%d item stack(s)", len(inv))
+}
+
+// indexSubscriber keeps recordings index up to date with saved and uploaded recordings.
+func indexSubscriber(idx *index.Index) func(events.Event) {
+	return func(e events.Event) {
+		var err error
+
+		switch e := e.(type) {
+		case events.RecordingSaved:
+			err = idx.Add(e.Filename)
+		case events.UploadQueued:
+			err = idx.SetUploadPending(e.Filename, nil)
+		case events.UploadRetrying:
+			err = idx.SetUploadPending(e.Filename, e.Err)
+		case events.UploadFinished:
+			err = idx.SetUploaded(e.Filename, e.Err)
+		default:
+			return
+		}
+
+		if err != nil {
+			log.Printf("failed updating recordings index: %v", err)
+		}
+	}
+}
+
+// valueSubscriber shows ISK value of loot gained during run and cost of fittings in overlay, when price source is set.
+// Every character's cargo is compared with its first snapshot of run.
+func valueSubscriber(o *overlay.Overlay, p *priceSource, fits recorder.FittingsSource) func(events.Event) {
+	// maps are ready before first recording, price source might get configured while recording is running
+	var (
+		fittingsCost float64
+		initial      = make(map[string]inventory.Inventory)
+		latest       = make(map[string]inventory.Inventory)
+	)
+
+	snapshot := func(character, loot string) {
+		inv, err := inventory.Parse(loot)
+		if err != nil {
+			return
+		}
+
+		if _, ok := initial[character]; !ok {
+			initial[character] = inv
+		}
+
+		latest[character] = inv
+	}
+
+	return func(e events.Event) {
+		if !p.Configured() {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		switch e := e.(type) {
+		case events.RecordingStarted:
+			initial, latest = make(map[string]inventory.Inventory), make(map[string]inventory.Inventory)
+			fittingsCost = 0
+
+			for _, character := range e.Characters {
+				fittingsCost += fittingCost(ctx, p, fits.FittingForPilot(character))
+			}
+
+			o.ChangeProperty(overlay.Value, fmt.Sprintf("Fittings: %s", prices.FormatISK(fittingsCost)), &overlay.CyanColor)
+
+			return
+		case events.InitialLootCaptured:
+			snapshot(e.Character, e.Loot)
+		case events.LootCaptured:
+			snapshot(e.Character, e.Loot)
+		default:
+			return
+		}
+
+		gained := make(map[string]int64)
+
+		for character, inv := range latest {
+			for _, c := range inventory.Diff(initial[character], inv).Gained {
+				gained[c.Name] += c.Quantity
+			}
+		}
+
+		value, missing, err := prices.Lookup(ctx, p, gained)
+		if err != nil {
+			log.Printf("failed pricing loot: %v", err)
+			o.ChangeProperty(overlay.Value, "Loot value: pricing failed", &overlay.RedColor)
+
+			return
+		}
+
+		text := fmt.Sprintf("Loot: %s, fittings: %s", prices.FormatISK(value), prices.FormatISK(fittingsCost))
+		if len(missing) > 0 {
+			text += fmt.Sprintf(" (%d items without price)", len(missing))
+		}
+
+		o.ChangeProperty(overlay.Value, text, &overlay.GreenColor)
+	}
+}
+
+// fittingCost returns price of fitting, fitting without price is priced by its EFT.
+func fittingCost(ctx context.Context, p prices.PriceProvider, fit *encoding.Fit) float64 {
+	if fit.GetPrice() > 0 || fit.GetEFT() == "" {
+		return fit.GetPrice()
+	}
+
+	cost, _, err := prices.FittingCost(ctx, p, fit.GetEFT())
+	if err != nil {
+		log.Printf("failed pricing fitting %q: %v", fit.GetFittingName(), err)
+	}
+
+	return cost
+}
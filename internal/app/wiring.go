@@ -1,21 +1,26 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"image"
 	"log/slog"
+	"net/http"
 	"os"
+	"path/filepath"
 
 	"github.com/lxn/walk"
 
 	"github.com/shivas/abyss-blackbox/internal/app/api/client"
 	"github.com/shivas/abyss-blackbox/internal/app/domain"
+	"github.com/shivas/abyss-blackbox/internal/app/events"
 	"github.com/shivas/abyss-blackbox/internal/app/recorder"
 	"github.com/shivas/abyss-blackbox/internal/charmanager"
 	"github.com/shivas/abyss-blackbox/internal/config"
 	"github.com/shivas/abyss-blackbox/internal/fittings"
 	"github.com/shivas/abyss-blackbox/internal/fittings/provider"
+	"github.com/shivas/abyss-blackbox/internal/index"
 	"github.com/shivas/abyss-blackbox/internal/mainwindow"
 	"github.com/shivas/abyss-blackbox/internal/overlay"
 	"github.com/shivas/abyss-blackbox/internal/screen"
@@ -24,9 +29,12 @@ import (
 	"github.com/shivas/abyss-blackbox/pkg/combatlog"
 )
 
+// uploadQueueFile is file in application folder recordings waiting for upload are stored in.
+const uploadQueueFile = "uploads.json"
+
 var (
 	previewChannel      chan image.Image
-	recordingChannel    chan *image.Paletted
+	recordingChannel    chan domain.Frame
 	notificationChannel chan domain.NotificationMessage
 	rec                 *recorder.Recorder
 )
@@ -39,7 +47,7 @@ func Run() (err error) {
 	}
 
 	previewChannel = make(chan image.Image, 10)
-	recordingChannel = make(chan *image.Paletted, 10)
+	recordingChannel = make(chan domain.Frame, 10)
 	notificationChannel = make(chan domain.NotificationMessage, 10)
 
 	windowsManager, err := window.NewManager()
@@ -56,12 +64,17 @@ func Run() (err error) {
 
 	overlayManager.ChangeProperty(overlay.Status, "Recorder on standby", &overlay.YellowColor)
 
+	// additional gamelog languages, optional
+	if f, errr := os.Open(filepath.Join(currentSettings.AppRoot, "languages.json")); errr == nil {
+		if errr = combatlog.RegisterLanguages(f); errr != nil {
+			slog.Error("failed loading languages.json", "error", errr)
+		}
+
+		f.Close()
+	}
+
 	// combatlog reader init
 	clr := combatlog.NewReader(currentSettings.EVEGameLogsFolder)
-	rec = recorder.NewRecorder(recordingChannel, currentSettings, notificationChannel, clr, overlayManager)
-	rec.StartLoop()
-
-	defer rec.StopLoop()
 
 	comboModel := make([]*mainwindow.WindowComboBoxItem, 0)
 	for handle, title := range windowsManager.GetEVEClientWindows(window.SupportedWindowsFilter) {
@@ -74,6 +87,38 @@ func Run() (err error) {
 
 	authenticatedHTTPClient := client.New(charManager)
 	fittingsManager := fittings.NewManager(authenticatedHTTPClient, provider.NewTrackerFittingsProvider(authenticatedHTTPClient))
+	priceProvider := &priceSource{config: currentSettings}
+	fittingsManager.SetPriceProvider(priceProvider)
+
+	bus := events.NewBus()
+	defer bus.Close()
+
+	bus.Subscribe(logSubscriber)
+	bus.Subscribe(overlaySubscriber(overlayManager))
+	bus.Subscribe(notificationSubscriber(notificationChannel))
+	bus.Subscribe(valueSubscriber(overlayManager, priceProvider, fittingsSource{manager: fittingsManager}))
+
+	recordingsIndex, err := index.Open(currentSettings.Recordings)
+	if err != nil {
+		slog.Error("failed loading recordings index, rebuilding it", "error", err)
+
+		recordingsIndex = index.New(currentSettings.Recordings)
+	}
+
+	bus.Subscribe(indexSubscriber(recordingsIndex))
+
+	clipboard := make(clipboardSource, 2)
+	rec = recorder.NewRecorder(
+		frameSource(recordingChannel),
+		clipboard,
+		settingsSource{config: currentSettings},
+		bus,
+		fittingsSource{manager: fittingsManager},
+		clr,
+	)
+	rec.StartLoop()
+
+	defer rec.StopLoop()
 
 	actions := make(map[string]walk.EventHandler)
 	actions["add_character"] = charManager.EventHandlerCharAdd
@@ -120,6 +165,43 @@ func Run() (err error) {
  Please reconfigure EVE client accordingly and restart this application.`, walk.MsgBoxIconWarning)
 	}
 
+	recoverUnfinishedRecordings(armw.MainWindow, rec)
+
+	// uploads queued before restart continue right away
+	queueFile := filepath.Join(currentSettings.AppRoot, uploadQueueFile)
+
+	uploads, err := uploader.NewQueue(authenticatedHTTPClient, queueFile)
+	if err != nil {
+		slog.Error("failed loading upload queue, starting with empty one", "error", err)
+		_ = os.Rename(queueFile, queueFile+".damaged")
+
+		if uploads, err = uploader.NewQueue(authenticatedHTTPClient, queueFile); err != nil {
+			return err
+		}
+	}
+
+	uploads.Start(func(result uploader.Result) {
+		if result.Retrying() {
+			bus.Publish(events.UploadRetrying{Filename: result.Filename, Attempts: result.Attempts, NextAttempt: result.NextAttempt, Err: result.Err})
+			return
+		}
+
+		// reported by notification, modal dialog here would hold up whole queue
+		bus.Publish(events.UploadFinished{Filename: result.Filename, Result: result.Response, Err: result.Err})
+	})
+	defer uploads.Stop()
+
+	// index recordings made while recorder was not running (and recovered ones)
+	go func() {
+		updated, removed, errr := recordingsIndex.Scan()
+		if errr != nil {
+			slog.Error("failed scanning recordings folder", "error", errr)
+			return
+		}
+
+		slog.Info("recordings index updated", "indexed", updated, "removed", removed)
+	}()
+
 	notificationIcon := createNotificationIcon(armw.MainWindow)
 
 	defer func() {
@@ -130,7 +212,11 @@ func Run() (err error) {
 	go func(nc chan domain.NotificationMessage, ni *walk.NotifyIcon) {
 		for msg := range nc {
 			if !currentSettings.SuppressNotifications {
-				_ = ni.ShowMessage(msg.Title, msg.Message)
+				msg := msg
+
+				armw.MainWindow.Synchronize(func() {
+					_ = ni.ShowMessage(msg.Title, msg.Message)
+				})
 			}
 		}
 	}(notificationChannel, notificationIcon)
@@ -150,7 +236,10 @@ func Run() (err error) {
 					return
 				}
 
-				rec.Start(checkedChars)
+				if errr := rec.Start(checkedChars); errr != nil {
+					walk.MsgBox(armw.MainWindow, "Error starting recording", errr.Error(), walk.MsgBoxIconWarning)
+					return
+				}
 			}
 
 			overlayManager.ChangeProperty(overlay.Autoupload, fmt.Sprintf("Autoupload enabled: %t", armw.AutoUploadCheckbox.Checked()), &overlay.CyanColor)
@@ -158,7 +247,7 @@ func Run() (err error) {
 			if currentSettings.AbyssTypeOverride {
 				overlayManager.ChangeProperty(overlay.Override, fmt.Sprintf("Abyss type override: %s", tierOverrideToString(currentSettings)), &overlay.SecondaryColor)
 			} else {
-				overlayManager.ChangeProperty(overlay.Override, "Abyss type detection: heuristics", &overlay.CyanColor)
+				overlayManager.ChangeProperty(overlay.Override, "Abyss type detection: from filament", &overlay.CyanColor)
 			}
 
 			_ = armw.MainWindow.Menu().Actions().At(0).SetVisible(false)
@@ -170,7 +259,7 @@ func Run() (err error) {
 			_ = armw.Toolbar.Actions().At(3).SetEnabled(false)
 			_ = armw.RecordingButton.SetText("Stop recording")
 		} else {
-			filename, errr := rec.Stop(armw.FittingManager)
+			filename, errr := rec.Stop()
 			if errr != nil {
 				walk.MsgBox(armw.MainWindow, "Error writing recording", errr.Error(), walk.MsgBoxIconWarning)
 			}
@@ -178,15 +267,11 @@ func Run() (err error) {
 			char := charManager.ActiveCharacter()
 
 			if armw.AutoUploadCheckbox.Checked() && char != nil && errr == nil {
-				go func(fn string) {
-					uploadFile, uploadErr := uploader.Upload(authenticatedHTTPClient, fn)
-					if uploadErr != nil {
-						walk.MsgBox(armw.MainWindow, "Record uploading error", uploadErr.Error(), walk.MsgBoxIconWarning)
-					} else {
-						notificationChannel <- domain.NotificationMessage{Title: "Record uploaded successfully", Message: uploadFile}
-						overlayManager.ChangeProperty(overlay.TODO, "Record uploaded successfully", &overlay.GreenColor)
-					}
-				}(filename)
+				if errr = uploads.Enqueue(filename); errr != nil {
+					walk.MsgBox(armw.MainWindow, "Record uploading error", errr.Error(), walk.MsgBoxIconWarning)
+				} else {
+					bus.Publish(events.UploadQueued{Filename: filename})
+				}
 			}
 
 			_ = armw.MainWindow.Menu().Actions().At(0).SetVisible(true)
@@ -197,13 +282,18 @@ func Run() (err error) {
 			//armw.TestServer.SetEnabled(true)
 			_ = armw.Toolbar.Actions().At(3).SetEnabled(true)
 			_ = armw.RecordingButton.SetText("Start recording")
-
-			overlayManager.ChangeProperty(overlay.Status, "Recorder on standby", &overlay.YellowColor)
-			overlayManager.ChangeProperty(overlay.Weather, "", nil)
 		}
 	}
 
 	armw.RecordingButton.Clicked().Attach(recordingButtonHandler)
+	armw.UploadRecordingsAction.Triggered().Attach(func() {
+		if charManager.ActiveCharacter() == nil {
+			walk.MsgBox(armw.MainWindow, "No active character", "Please add character and make it active to upload recordings", walk.MsgBoxIconWarning)
+			return
+		}
+
+		uploadRecordings(armw, recordingsIndex, authenticatedHTTPClient, bus)
+	})
 	armw.PresetSaveButton.Clicked().Attach(func() {
 		p := config.Preset{X: currentSettings.X, Y: currentSettings.Y, H: currentSettings.H}
 		_, _ = mainwindow.RunNewPresetDialog(armw.MainWindow, p, currentSettings)
@@ -243,7 +333,7 @@ func Run() (err error) {
 		recordingChannel,
 	)
 
-	walk.Clipboard().ContentsChanged().Attach(rec.ClipboardListener)
+	walk.Clipboard().ContentsChanged().Attach(clipboard.Listener)
 
 	defer func() {
 		err = config.Write(currentSettings)
@@ -258,6 +348,60 @@ func Run() (err error) {
 	return nil
 }
 
+// recoverUnfinishedRecordings offers to recover journals left behind by recordings that were never stopped.
+func recoverUnfinishedRecordings(mw *walk.MainWindow, r *recorder.Recorder) {
+	for _, journal := range r.UnfinishedJournals() {
+		answer := walk.MsgBox(mw, "Unfinished recording found",
+			fmt.Sprintf("Recording %q was not stopped properly (application crash or power loss).\n\nDo you want to recover it into .abyss file?", journal),
+			walk.MsgBoxYesNo|walk.MsgBoxIconQuestion)
+		if answer != walk.DlgCmdYes {
+			continue
+		}
+
+		filename, err := r.RecoverJournal(journal)
+		if err != nil {
+			walk.MsgBox(mw, "Recording recovery failed", err.Error(), walk.MsgBoxIconWarning)
+			continue
+		}
+
+		walk.MsgBox(mw, "Recording recovered", fmt.Sprintf("Recording recovered to file: %s", filename), walk.MsgBoxIconInformation)
+	}
+}
+
+// uploadRecordings uploads recordings that were not uploaded yet in background, after user confirms it.
+func uploadRecordings(armw *mainwindow.AbyssRecorderWindow, idx *index.Index, httpClient *http.Client, publisher recorder.Publisher) {
+	if _, _, err := idx.Scan(); err != nil {
+		walk.MsgBox(armw.MainWindow, "Error scanning recordings", err.Error(), walk.MsgBoxIconWarning)
+		return
+	}
+
+	filenames := idx.NotUploaded()
+	if len(filenames) == 0 {
+		walk.MsgBox(armw.MainWindow, "Nothing to upload", "All recordings are uploaded already", walk.MsgBoxIconInformation)
+		return
+	}
+
+	answer := walk.MsgBox(armw.MainWindow, "Upload recordings",
+		fmt.Sprintf("%d recording(s) in %s were not uploaded yet.\n\nDo you want to upload them now?", len(filenames), idx.Folder()),
+		walk.MsgBoxYesNo|walk.MsgBoxIconQuestion)
+	if answer != walk.DlgCmdYes {
+		return
+	}
+
+	_ = armw.UploadRecordingsAction.SetEnabled(false)
+
+	go func() {
+		uploader.UploadAll(context.Background(), httpClient, filenames, uploader.DefaultConcurrency, func(p uploader.Progress) {
+			publisher.Publish(events.UploadFinished{Filename: p.Result.Filename, Result: p.Result.Response, Err: p.Result.Err, Bulk: true})
+			publisher.Publish(events.BulkUploadProgress{Done: p.Done, Total: p.Total, Failed: p.Failed})
+		})
+
+		armw.MainWindow.Synchronize(func() {
+			_ = armw.UploadRecordingsAction.SetEnabled(true)
+		})
+	}()
+}
+
 // createNotificationIcon creates walk.NotifyIcon that can be used to send notifications to user
 func createNotificationIcon(mw *walk.MainWindow) *walk.NotifyIcon {
 	// We load our icon from a file.
@@ -285,6 +285,19 @@ func downloadCharacterPortrait(charID int32, size int) ([]byte, error) {
 	return ioutil.ReadAll(resp.Body)
 }
 
+// Characters returns characters added to recorder.
+func (c *CharManager) Characters() []Character {
+	c.RLock()
+	defer c.RUnlock()
+
+	characters := make([]Character, 0, len(c.characters))
+	for _, char := range c.characters {
+		characters = append(characters, char)
+	}
+
+	return characters
+}
+
 func (c *CharManager) ActiveCharacter() *Character {
 	c.Lock()
 	defer c.Unlock()
@@ -45,15 +45,25 @@ type CaptureConfig struct {
 	OverlayShortcutText     string
 	OverlayShortcut         walk.Shortcut
 	LootRecordDiscriminator string
-	ActiveCharacter         int32
-	AutoUpload              bool
-	AbyssTypeOverride       bool
-	AbyssShipType           int
-	AbyssTier               int
-	AbyssWeather            string
-	SuppressNotifications   bool
-	OverlayPosition         walk.Rectangle
-	OverlayConfig           struct {
+	// LootRecordDiscriminatorQuantities are "Character=quantity" pairs of discriminator quantity carried by each character.
+	LootRecordDiscriminatorQuantities string
+	ActiveCharacter                   int32
+	AutoUpload                        bool
+	AbyssTypeOverride                 bool
+	AbyssShipType                     int
+	AbyssTier                         int
+	AbyssWeather                      string
+	SuppressNotifications             bool
+	CompactOverview                   bool
+	// RecordAnyClipboard records every clipboard change as loot, not only copies of inventory.
+	RecordAnyClipboard bool
+	// RecordingCodec is name of compression codec of recordings (gzip or zstd).
+	RecordingCodec string
+	// PricesFile is CSV or JSON file of item prices, PricesURL is price service asked for items missing in it.
+	PricesFile      string
+	PricesURL       string
+	OverlayPosition walk.Rectangle
+	OverlayConfig   struct {
 		FontFamily      string
 		FontSize        int
 		BackgroundColor string
@@ -143,6 +153,7 @@ func Read() (*CaptureConfig, error) {
 			AbyssTier:               0,
 			AbyssWeather:            "Dark",
 			SuppressNotifications:   false,
+			RecordingCodec:          "gzip",
 			OverlayConfig: struct {
 				FontFamily      string
 				FontSize        int
@@ -219,6 +230,10 @@ func Read() (*CaptureConfig, error) {
 			c.AbyssWeather = "Dark"
 		}
 
+		if c.RecordingCodec == "" {
+			c.RecordingCodec = "gzip"
+		}
+
 		if c.OverlayConfig.Spacing == 0 && c.OverlayConfig.FontSize == 0 {
 			c.OverlayConfig.FontFamily = "Verdana"
 			c.OverlayConfig.Spacing = 11
@@ -2,16 +2,20 @@ package fittings
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/shivas/abyss-blackbox/internal/fittings/pb"
 	"github.com/shivas/abyss-blackbox/internal/fittings/provider"
+	"github.com/shivas/abyss-blackbox/pkg/prices"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -19,9 +23,11 @@ import (
 //go:generate protoc -I ../../protobuf/ --go_opt=module=github.com/shivas/abyss-blackbox/internal/fittings/pb --go_out=./pb fittings-cache.proto
 
 const (
-	cacheFileName = "fittings.db"
-	iconSize      = 32
-	version       = 1
+	cacheFileName  = "fittings.db"
+	iconSize       = 32
+	version        = 1
+	esiTimeout     = 10 * time.Second
+	pricingTimeout = 10 * time.Second
 )
 
 type FittingsManager struct {
@@ -29,6 +35,7 @@ type FittingsManager struct {
 	cache           pb.FittingsCache
 	importProviders []provider.FittingsProvider
 	httpClient      *http.Client
+	prices          prices.PriceProvider
 }
 
 func NewManager(httpClient *http.Client, providers ...provider.FittingsProvider) *FittingsManager {
@@ -42,11 +49,22 @@ func NewManager(httpClient *http.Client, providers ...provider.FittingsProvider)
 
 	m.cache.Version = version
 	m.importProviders = providers
-	_ = m.LoadCache()
+
+	if err := m.LoadCache(); err == nil {
+		go m.fillShipGroups()
+	}
 
 	return m
 }
 
+// SetPriceProvider sets provider of prices fittings are priced with when added.
+func (m *FittingsManager) SetPriceProvider(p prices.PriceProvider) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.prices = p
+}
+
 func (m *FittingsManager) ClearAssignments() {
 	m.Lock()
 	defer m.Unlock()
@@ -129,9 +147,6 @@ func (m *FittingsManager) GetFittingForPilot(characterName string) *pb.FittingRe
 }
 
 func (m *FittingsManager) AddFitting(r *pb.FittingRecord) (ID int, fitting *pb.FittingRecord, err error) {
-	m.Lock()
-	defer m.Unlock()
-
 	ID = -1
 
 	if err = r.Validate(); err != nil {
@@ -143,11 +158,106 @@ func (m *FittingsManager) AddFitting(r *pb.FittingRecord) (ID int, fitting *pb.F
 		return
 	}
 
+	if r.ShipGroupID, err = fetchTypeGroupID(r.ShipTypeID); err != nil {
+		log.Printf("failed looking up ship group of fitting %q, ship class stays unknown: %v", r.FittingName, err)
+		err = nil
+	}
+
+	r.Price = m.fittingPrice(r)
+
+	m.Lock()
+	defer m.Unlock()
+
 	m.cache.Fittings = append(m.cache.Fittings, r)
 
 	return len(m.cache.Fittings), r, nil
 }
 
+// fittingPrice prices fitting with price provider set, 0 (price unknown) when provider is not set or pricing fails.
+func (m *FittingsManager) fittingPrice(r *pb.FittingRecord) float64 {
+	m.Lock()
+	p := m.prices
+	m.Unlock()
+
+	if p == nil {
+		return 0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pricingTimeout)
+	defer cancel()
+
+	cost, missing, err := prices.FittingCost(ctx, p, r.EFT)
+	if err != nil {
+		log.Printf("failed pricing fitting %q, price stays unknown: %v", r.FittingName, err)
+		return 0
+	}
+
+	if len(missing) > 0 {
+		log.Printf("fitting %q priced without: %v", r.FittingName, missing)
+	}
+
+	return cost
+}
+
+// GetShipGroupForPilot returns inventory group of ship fitted for character, 0 if unknown.
+func (m *FittingsManager) GetShipGroupForPilot(characterName string) int32 {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.cache.CharactersFittings[characterName].GetShipGroupID()
+}
+
+// fillShipGroups looks up ship groups missing in fittings cached by older versions, cache is saved when any was found.
+func (m *FittingsManager) fillShipGroups() {
+	m.Lock()
+	missing := make(map[int32]bool)
+
+	for _, f := range m.records() {
+		if f.ShipGroupID == 0 && f.ShipTypeID != 0 {
+			missing[f.ShipTypeID] = true
+		}
+	}
+	m.Unlock()
+
+	groups := make(map[int32]int32, len(missing))
+
+	for typeID := range missing {
+		groupID, err := fetchTypeGroupID(typeID)
+		if err != nil {
+			log.Printf("failed looking up ship group of type %d: %v", typeID, err)
+			continue
+		}
+
+		groups[typeID] = groupID
+	}
+
+	if len(groups) == 0 {
+		return
+	}
+
+	m.Lock()
+	for _, f := range m.records() {
+		if groupID, ok := groups[f.ShipTypeID]; ok && f.ShipGroupID == 0 {
+			f.ShipGroupID = groupID
+		}
+	}
+	m.Unlock()
+
+	if err := m.PersistCache(); err != nil {
+		log.Printf("failed saving fittings cache: %v", err)
+	}
+}
+
+// records returns every cached fitting record, including ones assigned to characters. Lock must be held.
+func (m *FittingsManager) records() []*pb.FittingRecord {
+	records := append([]*pb.FittingRecord{}, m.cache.Fittings...)
+	for _, f := range m.cache.CharactersFittings {
+		records = append(records, f)
+	}
+
+	return records
+}
+
 func (m *FittingsManager) GetByID(ID int) *pb.FittingRecord {
 	m.Lock()
 	defer m.Unlock()
@@ -229,3 +339,36 @@ func downloadTypeIDIcon(typeID int32, size int) ([]byte, error) {
 
 	return ioutil.ReadAll(resp.Body)
 }
+
+// fetchTypeGroupID returns inventory group of type from ESI. Plain client is used, application client would send
+// abyssal.space token along.
+func fetchTypeGroupID(typeID int32) (int32, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), esiTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://esi.evetech.net/latest/universe/types/%d/", typeID), http.NoBody)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var t struct {
+		GroupID int32 `json:"group_id"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return 0, err
+	}
+
+	return t.GroupID, nil
+}
@@ -97,6 +97,8 @@ type FittingRecord struct {
 	ShipName    string  `protobuf:"bytes,7,opt,name=shipName,proto3" json:"shipName,omitempty"`
 	ShipTypeID  int32   `protobuf:"varint,8,opt,name=shipTypeID,proto3" json:"shipTypeID,omitempty"`
 	Icon        []byte  `protobuf:"bytes,9,opt,name=icon,proto3" json:"icon,omitempty"`
+	// shipGroupID is inventory group of ship, it tells ship class. 0 when unknown, as for fittings cached by older versions.
+	ShipGroupID int32 `protobuf:"varint,10,opt,name=shipGroupID,proto3" json:"shipGroupID,omitempty"`
 }
 
 func (x *FittingRecord) Reset() {
@@ -194,6 +196,13 @@ func (x *FittingRecord) GetIcon() []byte {
 	return nil
 }
 
+func (x *FittingRecord) GetShipGroupID() int32 {
+	if x != nil {
+		return x.ShipGroupID
+	}
+	return 0
+}
+
 var File_fittings_cache_proto protoreflect.FileDescriptor
 
 var file_fittings_cache_proto_rawDesc = []byte{
@@ -217,7 +226,7 @@ var file_fittings_cache_proto_rawDesc = []byte{
 	0x2d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x74, 0x74, 0x69, 0x6e,
 	0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x22, 0x84, 0x02, 0x0a, 0x0d, 0x46, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x38, 0x01, 0x22, 0xa6, 0x02, 0x0a, 0x0d, 0x46, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65,
 	0x63, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x09,
 	0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48,
@@ -232,12 +241,14 @@ var file_fittings_cache_proto_rawDesc = []byte{
 	0x68, 0x69, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x68, 0x69, 0x70, 0x54,
 	0x79, 0x70, 0x65, 0x49, 0x44, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x68, 0x69,
 	0x70, 0x54, 0x79, 0x70, 0x65, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x18,
-	0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f,
-	0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x49, 0x44, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x68, 0x69, 0x76, 0x61, 0x73, 0x2f, 0x61,
-	0x62, 0x79, 0x73, 0x73, 0x2d, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x62, 0x6f, 0x78, 0x2f, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x66, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x2f,
-	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x73,
+	0x68, 0x69, 0x70, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x73, 0x68, 0x69, 0x70, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x42, 0x0c, 0x0a,
+	0x0a, 0x5f, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x49, 0x44, 0x42, 0x37, 0x5a, 0x35, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x68, 0x69, 0x76, 0x61, 0x73,
+	0x2f, 0x61, 0x62, 0x79, 0x73, 0x73, 0x2d, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x62, 0x6f, 0x78, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x66, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -76,7 +76,7 @@ func (r *FittingRecord) Validate() error {
 	r.ShipTypeID = int32(validateResponse.ShipTypeID)
 	r.ShipName = validateResponse.Ship
 	r.FittingName = validateResponse.FittingName
-	r.Price = 0.0 // perform price check?
+	r.Price = 0.0 // priced by fittings manager, when price source is set
 	r.FFH = validateResponse.FFH
 
 	return nil
@@ -0,0 +1,404 @@
+package index
+
+//go:generate protoc -I ../../protobuf/ --go_opt=paths=source_relative --go_out=. recordings-index.proto
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// FileName is name of index file kept inside recordings folder.
+	FileName = "recordings.index"
+	// recordingNameLayout is layout recorder names recording files with.
+	recordingNameLayout = "2006-Jan-2-15-04-05"
+	version             = 3
+)
+
+// ErrOutsideFolder returned when recording being added is not in recordings folder of index.
+var ErrOutsideFolder = errors.New("recording is not in indexed folder")
+
+// Index holds metadata of every recording in recordings folder, so history can be browsed without decoding every file.
+type Index struct {
+	mu     sync.Mutex
+	folder string
+	data   RecordingsIndex
+}
+
+// New creates empty index of recordings folder, Scan fills it.
+func New(folder string) *Index {
+	idx := &Index{folder: folder}
+	idx.data.Version = version
+
+	return idx
+}
+
+// Open opens index of recordings folder, missing index file results in empty index (see Scan).
+func Open(folder string) (*Index, error) {
+	idx := New(folder)
+
+	data, err := os.ReadFile(filepath.Join(folder, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return idx, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = proto.Unmarshal(data, &idx.data); err != nil {
+		return nil, err
+	}
+
+	// index written by older version misses metadata, next Scan indexes every recording again
+	if idx.data.Version < version {
+		for _, r := range idx.data.Recordings {
+			r.ModTime = 0
+		}
+
+		idx.data.Version = version
+	}
+
+	return idx, nil
+}
+
+// Folder returns recordings folder of index.
+func (idx *Index) Folder() string {
+	return idx.folder
+}
+
+// Scan indexes recordings added or changed since last scan and drops recordings no longer in folder.
+// Upload status of recordings already indexed is kept. Index is saved when it changed.
+func (idx *Index) Scan() (updated, removed int, err error) {
+	files, err := filepath.Glob(filepath.Join(idx.folder, "*.abyss"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	present := make(map[string]bool, len(files))
+
+	for _, file := range files {
+		name := filepath.Base(file)
+		present[name] = true
+
+		info, errr := os.Stat(file)
+		if errr != nil {
+			continue
+		}
+
+		r := idx.find(name)
+		if r != nil && r.Size == info.Size() && r.ModTime == info.ModTime().UnixMilli() {
+			continue
+		}
+
+		indexed := indexFile(file, info)
+		if r == nil {
+			indexed.UploadStatus = IndexedRecording_UPLOAD_UNKNOWN
+		}
+
+		idx.upsert(indexed)
+		updated++
+	}
+
+	recordings := idx.data.Recordings[:0]
+
+	for _, r := range idx.data.Recordings {
+		if present[r.Filename] {
+			recordings = append(recordings, r)
+		} else {
+			removed++
+		}
+	}
+
+	idx.data.Recordings = recordings
+
+	if updated == 0 && removed == 0 {
+		return 0, 0, nil
+	}
+
+	return updated, removed, idx.save()
+}
+
+// Add indexes single recording, indexing it again if already indexed, and saves index.
+func (idx *Index) Add(filename string) error {
+	if filepath.Clean(filepath.Dir(filename)) != filepath.Clean(idx.folder) {
+		return ErrOutsideFolder
+	}
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.upsert(indexFile(filename, info))
+
+	return idx.save()
+}
+
+// SetUploaded records result of recording upload, failed upload when uploadErr is set, and saves index.
+func (idx *Index) SetUploaded(filename string, uploadErr error) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	r := idx.find(filepath.Base(filename))
+	if r == nil {
+		return fs.ErrNotExist
+	}
+
+	r.LastAttemptAt = time.Now().UnixMilli()
+
+	if uploadErr != nil {
+		r.UploadStatus = IndexedRecording_UPLOAD_FAILED
+		r.UploadError = uploadErr.Error()
+
+		return idx.save()
+	}
+
+	r.UploadedAt = r.LastAttemptAt
+	r.UploadStatus = IndexedRecording_UPLOADED
+	r.UploadError = ""
+
+	return idx.save()
+}
+
+// SetUploadPending records that recording waits in upload queue, lastErr is error of last failed attempt if any, and saves index.
+func (idx *Index) SetUploadPending(filename string, lastErr error) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	r := idx.find(filepath.Base(filename))
+	if r == nil {
+		return fs.ErrNotExist
+	}
+
+	r.UploadStatus = IndexedRecording_UPLOAD_PENDING
+	r.UploadError = ""
+
+	if lastErr != nil {
+		r.LastAttemptAt = time.Now().UnixMilli()
+		r.UploadError = lastErr.Error()
+	}
+
+	return idx.save()
+}
+
+// Recordings returns copy of indexed recordings, newest first.
+func (idx *Index) Recordings() []*IndexedRecording {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	recordings := make([]*IndexedRecording, 0, len(idx.data.Recordings))
+	for _, r := range idx.data.Recordings {
+		recordings = append(recordings, proto.Clone(r).(*IndexedRecording))
+	}
+
+	sort.SliceStable(recordings, func(i, j int) bool {
+		if recordings[i].RecordedAt != recordings[j].RecordedAt {
+			return recordings[i].RecordedAt > recordings[j].RecordedAt
+		}
+
+		return recordings[i].Filename > recordings[j].Filename
+	})
+
+	return recordings
+}
+
+// NotUploaded returns paths of readable recordings never uploaded or whose upload failed, oldest first.
+// Recordings waiting in upload queue are left out.
+func (idx *Index) NotUploaded() []string {
+	recordings := idx.Recordings()
+
+	var filenames []string
+
+	for i := len(recordings) - 1; i >= 0; i-- {
+		r := recordings[i]
+		if r.Error == "" && (r.UploadStatus == IndexedRecording_NOT_UPLOADED || r.UploadStatus == IndexedRecording_UPLOAD_FAILED) {
+			filenames = append(filenames, filepath.Join(idx.folder, r.Filename))
+		}
+	}
+
+	return filenames
+}
+
+// Get returns copy of indexed recording, nil when recording is not indexed.
+func (idx *Index) Get(filename string) *IndexedRecording {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	r := idx.find(filepath.Base(filename))
+	if r == nil {
+		return nil
+	}
+
+	return proto.Clone(r).(*IndexedRecording)
+}
+
+// RecordedAtTime returns time recording started.
+func (x *IndexedRecording) RecordedAtTime() time.Time {
+	return time.UnixMilli(x.GetRecordedAt())
+}
+
+// Completed tells whether run was completed, that is loot was captured after initial cargo.
+func (x *IndexedRecording) Completed() bool {
+	return x.GetError() == "" && x.GetLootRecords() >= 2
+}
+
+// Duration returns duration of recording.
+func (x *IndexedRecording) Duration() time.Duration {
+	return time.Duration(x.GetDurationMs()) * time.Millisecond
+}
+
+// HasAbyssType tells whether abyss tier, weather and ship type are known, chosen manually or detected by recorder.
+// Ship type of detected abyss type might still be unknown.
+func (x *IndexedRecording) HasAbyssType() bool {
+	return x.GetManualAbyssTypeOverride() || x.GetDetectedFilament() != ""
+}
+
+func (idx *Index) find(name string) *IndexedRecording {
+	for _, r := range idx.data.Recordings {
+		if r.Filename == name {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// upsert replaces indexed recording, keeping its upload status.
+func (idx *Index) upsert(r *IndexedRecording) {
+	for i, old := range idx.data.Recordings {
+		if old.Filename == r.Filename {
+			r.UploadStatus, r.UploadedAt, r.LastAttemptAt, r.UploadError = old.UploadStatus, old.UploadedAt, old.LastAttemptAt, old.UploadError
+			idx.data.Recordings[i] = r
+
+			return
+		}
+	}
+
+	idx.data.Recordings = append(idx.data.Recordings, r)
+}
+
+// save writes index to temporary file first, so crash never leaves index half written.
+func (idx *Index) save() error {
+	data, err := proto.Marshal(&idx.data)
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(idx.folder, FileName)
+
+	if err = os.WriteFile(filename+".tmp", data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(filename+".tmp", filename)
+}
+
+// indexFile decodes recording file into index entry, entry of file that can't be decoded carries error.
+func indexFile(filename string, info fs.FileInfo) *IndexedRecording {
+	r := &IndexedRecording{
+		Filename:   filepath.Base(filename),
+		Size:       info.Size(),
+		ModTime:    info.ModTime().UnixMilli(),
+		RecordedAt: recordedAtFromName(filepath.Base(filename), info.ModTime()).UnixMilli(),
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	defer f.Close()
+
+	rec, err := encoding.Decode(f)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+
+	if rec.StartedAt != 0 {
+		r.RecordedAt = rec.StartedAt
+	}
+
+	r.DurationMs = duration(rec).Milliseconds()
+	r.TestServer = rec.TestServer
+	r.ManualAbyssTypeOverride = rec.ManualAbyssTypeOverride
+	r.DetectedFilament = rec.DetectedFilament
+	r.AbyssShipType = rec.AbyssShipType
+	r.AbyssTier = rec.AbyssTier
+	r.AbyssWeather = rec.AbyssWheather
+	r.WeatherStrength = rec.WeatherStrength
+	r.LootRecordDiscriminator = rec.LootRecordDiscriminator
+	r.RecorderVersion = rec.RecorderVersion
+	r.LootRecords = int32(len(rec.Loot))
+
+	characters := make(map[string]bool)
+
+	for _, clr := range rec.CombatLog {
+		characters[clr.GetCharacterName()] = true
+	}
+
+	for character, fit := range rec.Fittings {
+		characters[character] = true
+
+		r.Fittings = append(r.Fittings, &IndexedFit{
+			Character:   character,
+			FittingName: fit.GetFittingName(),
+			ShipName:    fit.GetShipName(),
+			ShipTypeID:  fit.GetShipTypeID(),
+			FFH:         fit.GetFFH(),
+			Price:       fit.GetPrice(),
+		})
+	}
+
+	for character := range characters {
+		if character != "" {
+			r.Characters = append(r.Characters, character)
+		}
+	}
+
+	sort.Strings(r.Characters)
+	sort.Slice(r.Fittings, func(i, j int) bool { return r.Fittings[i].Character < r.Fittings[j].Character })
+
+	return r
+}
+
+// duration returns duration of recording from frame timestamps, falling back to frame count for older recordings.
+func duration(rec *encoding.AbyssRecording) time.Duration {
+	if first, ok := rec.FrameTime(0); ok {
+		last, _ := rec.FrameTime(len(rec.FrameTimestamps) - 1)
+		return last.Sub(first) + encoding.FrameInterval
+	}
+
+	frames, err := rec.FrameCount()
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(frames) * encoding.FrameInterval
+}
+
+// recordedAtFromName returns time recording started from its filename, modTime when file was renamed.
+func recordedAtFromName(name string, modTime time.Time) time.Time {
+	t, err := time.ParseInLocation(recordingNameLayout, strings.TrimSuffix(name, ".abyss"), time.Local)
+	if err != nil {
+		return modTime
+	}
+
+	return t
+}
@@ -0,0 +1,142 @@
+package index
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/combatlog"
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+func writeRecording(t *testing.T, filename string, rec *encoding.AbyssRecording) {
+	t.Helper()
+
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if err = rec.Encode(f); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	folder := t.TempDir()
+	started := time.Date(2023, 5, 1, 18, 30, 0, 0, time.UTC)
+
+	writeRecording(t, filepath.Join(folder, "2023-May-1-18-30-00.abyss"), &encoding.AbyssRecording{
+		Overview:         []byte("GIF89a"),
+		FrameTimestamps:  []int64{started.UnixMilli(), started.Add(time.Second).UnixMilli(), started.Add(2 * time.Second).UnixMilli()},
+		StartedAt:        started.UnixMilli(),
+		CombatLog:        []*combatlog.CombatLogRecord{{CharacterName: "Runner2"}, {CharacterName: "Runner1"}},
+		Fittings:         map[string]*encoding.Fit{"Runner1": {FittingName: "FFA", ShipName: "Gila", ShipTypeID: 17715, Price: 1.5e8}},
+		WeatherStrength:  50,
+		DetectedFilament: "Chaotic Electrical Filament",
+		AbyssShipType:    encoding.AbyssRecording_CRUISER,
+		AbyssTier:        5,
+		AbyssWheather:    "Electrical",
+		RecorderVersion:  "test",
+	})
+
+	if err := os.WriteFile(filepath.Join(folder, "broken.abyss"), []byte("not a recording"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := Open(folder)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	updated, removed, err := idx.Scan()
+	if err != nil || updated != 2 || removed != 0 {
+		t.Fatalf("Scan() = %d, %d, %v, want 2, 0, nil", updated, removed, err)
+	}
+
+	r := idx.Get("2023-May-1-18-30-00.abyss")
+	if r == nil {
+		t.Fatal("recording not indexed")
+	}
+
+	if !r.RecordedAtTime().Equal(started) || r.Duration() != 3*time.Second {
+		t.Errorf("recorded at %s for %s, want %s for 3s", r.RecordedAtTime(), r.Duration(), started)
+	}
+
+	if !reflect.DeepEqual(r.Characters, []string{"Runner1", "Runner2"}) {
+		t.Errorf("characters = %v", r.Characters)
+	}
+
+	if len(r.Fittings) != 1 || r.Fittings[0].ShipName != "Gila" || !r.HasAbyssType() || r.AbyssTier != 5 || r.AbyssWeather != "Electrical" || r.WeatherStrength != 50 || r.Error != "" {
+		t.Errorf("unexpected metadata: %v", r)
+	}
+
+	if broken := idx.Get("broken.abyss"); broken == nil || broken.Error == "" {
+		t.Errorf("broken recording indexed without error: %v", broken)
+	}
+
+	// recording found by scan might have been uploaded before index existed
+	if r.UploadStatus != IndexedRecording_UPLOAD_UNKNOWN || len(idx.NotUploaded()) != 0 {
+		t.Errorf("scanned recording upload status %s, NotUploaded() = %v", r.UploadStatus, idx.NotUploaded())
+	}
+
+	saved := filepath.Join(folder, "2023-May-1-19-30-00.abyss")
+	writeRecording(t, saved, &encoding.AbyssRecording{Overview: []byte("GIF89a")})
+
+	if err = idx.Add(saved); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if got, want := idx.NotUploaded(), []string{saved}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NotUploaded() = %v, want %v", got, want)
+	}
+
+	if err = os.Remove(saved); err != nil {
+		t.Fatal(err)
+	}
+
+	// failed attempt is not an upload
+	if err = idx.SetUploadPending(filepath.Join(folder, "2023-May-1-18-30-00.abyss"), errors.New("timeout")); err != nil {
+		t.Fatalf("SetUploadPending() error = %v", err)
+	}
+
+	if r = idx.Get("2023-May-1-18-30-00.abyss"); r.UploadedAt != 0 || r.LastAttemptAt == 0 || r.UploadError != "timeout" {
+		t.Errorf("after failed attempt uploaded at %d, last attempt at %d, error %q", r.UploadedAt, r.LastAttemptAt, r.UploadError)
+	}
+
+	if err = idx.SetUploaded(filepath.Join(folder, "2023-May-1-18-30-00.abyss"), nil); err != nil {
+		t.Fatalf("SetUploaded() error = %v", err)
+	}
+
+	if r = idx.Get("2023-May-1-18-30-00.abyss"); r.UploadedAt == 0 || r.UploadError != "" {
+		t.Errorf("after upload uploaded at %d, error %q", r.UploadedAt, r.UploadError)
+	}
+
+	if err = idx.SetUploaded("missing.abyss", errors.New("failed")); err == nil {
+		t.Error("SetUploaded() of recording not indexed succeeded")
+	}
+
+	if err = os.Remove(filepath.Join(folder, "broken.abyss")); err != nil {
+		t.Fatal(err)
+	}
+
+	// reopened index keeps upload status, unchanged files are not indexed again
+	idx, err = Open(folder)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	updated, removed, err = idx.Scan()
+	if err != nil || updated != 0 || removed != 2 {
+		t.Fatalf("Scan() = %d, %d, %v, want 0, 2, nil", updated, removed, err)
+	}
+
+	recordings := idx.Recordings()
+	if len(recordings) != 1 || recordings[0].UploadStatus != IndexedRecording_UPLOADED {
+		t.Errorf("Recordings() = %v", recordings)
+	}
+}
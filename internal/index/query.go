@@ -0,0 +1,224 @@
+package index
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sort orders accepted by SortRecordings.
+const (
+	SortByDate      = "date"
+	SortByDuration  = "duration"
+	SortByCharacter = "character"
+	SortByTier      = "tier"
+	SortByWeather   = "weather"
+)
+
+// AnyTier matches recordings of every tier.
+const AnyTier = -1
+
+// Filter selects indexed recordings, zero values match every recording (except Tier, use AnyTier).
+// Ship type, tier and weather are known only for recordings with abyss type override or detected abyss type.
+type Filter struct {
+	// From and To limit time recording started, To is exclusive.
+	From time.Time
+	To   time.Time
+	// Character matches any character of recording, case insensitive.
+	Character string
+	// Ship matches ship name of any fit or abyss ship type (cruiser, destroyer, frigate), case insensitive.
+	Ship    string
+	Tier    int
+	Weather string
+	// Upload matches upload status, nil matches any.
+	Upload *IndexedRecording_UploadStatus
+}
+
+// Match tells whether recording passes filter.
+func (f Filter) Match(r *IndexedRecording) bool {
+	recordedAt := r.RecordedAtTime()
+
+	switch {
+	case !f.From.IsZero() && recordedAt.Before(f.From):
+		return false
+	case !f.To.IsZero() && !recordedAt.Before(f.To):
+		return false
+	case f.Tier != AnyTier && (!r.HasAbyssType() || int(r.AbyssTier) != f.Tier):
+		return false
+	case f.Weather != "" && (!r.HasAbyssType() || !strings.EqualFold(r.AbyssWeather, f.Weather)):
+		return false
+	case f.Upload != nil && r.UploadStatus != *f.Upload:
+		return false
+	case f.Character != "" && !containsFold(r.Characters, f.Character):
+		return false
+	case f.Ship != "" && !f.matchShip(r):
+		return false
+	}
+
+	return true
+}
+
+func (f Filter) matchShip(r *IndexedRecording) bool {
+	if r.HasAbyssType() && strings.EqualFold(r.AbyssShipType.String(), f.Ship) {
+		return true
+	}
+
+	for _, fit := range r.Fittings {
+		if strings.EqualFold(fit.ShipName, f.Ship) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterRecordings returns recordings passing filter, in same order.
+func FilterRecordings(recordings []*IndexedRecording, f Filter) []*IndexedRecording {
+	var filtered []*IndexedRecording
+
+	for _, r := range recordings {
+		if f.Match(r) {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered
+}
+
+// ParseUploadStatus parses upload status name: uploaded, failed, pending, not-uploaded or unknown.
+func ParseUploadStatus(s string) (IndexedRecording_UploadStatus, error) {
+	switch strings.ToLower(s) {
+	case "uploaded":
+		return IndexedRecording_UPLOADED, nil
+	case "failed":
+		return IndexedRecording_UPLOAD_FAILED, nil
+	case "pending":
+		return IndexedRecording_UPLOAD_PENDING, nil
+	case "not-uploaded":
+		return IndexedRecording_NOT_UPLOADED, nil
+	case "unknown":
+		return IndexedRecording_UPLOAD_UNKNOWN, nil
+	default:
+		return 0, fmt.Errorf("unknown upload status: %q", s)
+	}
+}
+
+// SortRecordings sorts recordings in place, ascending unless reverse is set. Ties are ordered by date.
+func SortRecordings(recordings []*IndexedRecording, by string, reverse bool) error {
+	var less func(a, b *IndexedRecording) bool
+
+	switch by {
+	case SortByDate:
+		less = func(a, b *IndexedRecording) bool { return false }
+	case SortByDuration:
+		less = func(a, b *IndexedRecording) bool { return a.DurationMs < b.DurationMs }
+	case SortByCharacter:
+		less = func(a, b *IndexedRecording) bool {
+			return strings.Join(a.Characters, ",") < strings.Join(b.Characters, ",")
+		}
+	case SortByTier:
+		less = func(a, b *IndexedRecording) bool { return a.AbyssTier < b.AbyssTier }
+	case SortByWeather:
+		less = func(a, b *IndexedRecording) bool { return a.AbyssWeather < b.AbyssWeather }
+	default:
+		return fmt.Errorf("unknown sort order: %q", by)
+	}
+
+	sort.SliceStable(recordings, func(i, j int) bool {
+		a, b := recordings[i], recordings[j]
+		if reverse {
+			a, b = b, a
+		}
+
+		if less(a, b) {
+			return true
+		}
+
+		if less(b, a) {
+			return false
+		}
+
+		return a.RecordedAt < b.RecordedAt
+	})
+
+	return nil
+}
+
+// DayRuns is count of runs recorded during single day.
+type DayRuns struct {
+	Day  string `json:"day"` // YYYY-MM-DD, local time
+	Runs int    `json:"runs"`
+}
+
+// Stats are aggregate statistics of recordings.
+type Stats struct {
+	Runs            int           `json:"runs"`
+	Completed       int           `json:"completed"`
+	SuccessRate     float64       `json:"successRate"`
+	AverageDuration time.Duration `json:"averageDurationNs"`
+	Uploaded        int           `json:"uploaded"`
+	UploadFailed    int           `json:"uploadFailed"`
+	Unreadable      int           `json:"unreadable"`
+	RunsPerDay      []DayRuns     `json:"runsPerDay"`
+}
+
+// Summarize computes statistics of recordings. Unreadable recordings count as runs, but not into average duration.
+func Summarize(recordings []*IndexedRecording) Stats {
+	s := Stats{Runs: len(recordings), RunsPerDay: []DayRuns{}}
+	perDay := make(map[string]int)
+
+	var (
+		total    time.Duration
+		measured int
+	)
+
+	for _, r := range recordings {
+		perDay[r.RecordedAtTime().Format(time.DateOnly)]++
+
+		switch r.UploadStatus {
+		case IndexedRecording_UPLOADED:
+			s.Uploaded++
+		case IndexedRecording_UPLOAD_FAILED:
+			s.UploadFailed++
+		}
+
+		if r.Error != "" {
+			s.Unreadable++
+			continue
+		}
+
+		if r.Completed() {
+			s.Completed++
+		}
+
+		total += r.Duration()
+		measured++
+	}
+
+	if s.Runs > 0 {
+		s.SuccessRate = float64(s.Completed) / float64(s.Runs)
+	}
+
+	if measured > 0 {
+		s.AverageDuration = total / time.Duration(measured)
+	}
+
+	for day, runs := range perDay {
+		s.RunsPerDay = append(s.RunsPerDay, DayRuns{Day: day, Runs: runs})
+	}
+
+	sort.Slice(s.RunsPerDay, func(i, j int) bool { return s.RunsPerDay[i].Day < s.RunsPerDay[j].Day })
+
+	return s
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+
+	return false
+}
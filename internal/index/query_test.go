@@ -0,0 +1,123 @@
+package index
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+func testRecordings() []*IndexedRecording {
+	day := time.Date(2023, 5, 1, 18, 0, 0, 0, time.Local)
+
+	return []*IndexedRecording{
+		{
+			Filename: "a.abyss", RecordedAt: day.UnixMilli(), DurationMs: 1200000, LootRecords: 2,
+			Characters: []string{"Runner1"}, Fittings: []*IndexedFit{{Character: "Runner1", ShipName: "Gila"}},
+			ManualAbyssTypeOverride: true, AbyssShipType: encoding.AbyssRecording_CRUISER, AbyssTier: 5, AbyssWeather: "Dark",
+			UploadStatus: IndexedRecording_UPLOADED,
+		},
+		{
+			Filename: "b.abyss", RecordedAt: day.Add(time.Hour).UnixMilli(), DurationMs: 600000, LootRecords: 1,
+			Characters: []string{"Runner1", "Runner2"}, Fittings: []*IndexedFit{{Character: "Runner2", ShipName: "Worm"}},
+			DetectedFilament: "Fierce Gamma Filament", AbyssShipType: encoding.AbyssRecording_FRIGATE, AbyssTier: 3, AbyssWeather: "Gamma",
+			UploadStatus: IndexedRecording_UPLOAD_FAILED,
+		},
+		{
+			Filename: "c.abyss", RecordedAt: day.AddDate(0, 0, 1).UnixMilli(), DurationMs: 900000, LootRecords: 3,
+			Characters: []string{"Runner2"},
+		},
+		{
+			Filename: "d.abyss", RecordedAt: day.AddDate(0, 0, 1).Add(time.Hour).UnixMilli(), Error: "unexpected EOF",
+		},
+	}
+}
+
+func filenames(recordings []*IndexedRecording) []string {
+	names := []string{}
+	for _, r := range recordings {
+		names = append(names, r.Filename)
+	}
+
+	return names
+}
+
+func TestFilterRecordings(t *testing.T) {
+	failed := IndexedRecording_UPLOAD_FAILED
+	notUploaded := IndexedRecording_NOT_UPLOADED
+	day := time.Date(2023, 5, 2, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"all", Filter{Tier: AnyTier}, []string{"a.abyss", "b.abyss", "c.abyss", "d.abyss"}},
+		{"from", Filter{Tier: AnyTier, From: day}, []string{"c.abyss", "d.abyss"}},
+		{"to", Filter{Tier: AnyTier, To: day}, []string{"a.abyss", "b.abyss"}},
+		{"character", Filter{Tier: AnyTier, Character: "runner2"}, []string{"b.abyss", "c.abyss"}},
+		{"ship name", Filter{Tier: AnyTier, Ship: "gila"}, []string{"a.abyss"}},
+		{"ship type", Filter{Tier: AnyTier, Ship: "frigate"}, []string{"b.abyss"}},
+		{"tier", Filter{Tier: 5}, []string{"a.abyss"}},
+		{"detected tier", Filter{Tier: 3}, []string{"b.abyss"}},
+		{"tier 0 without override", Filter{Tier: 0}, []string{}},
+		{"weather", Filter{Tier: AnyTier, Weather: "gamma"}, []string{"b.abyss"}},
+		{"upload failed", Filter{Tier: AnyTier, Upload: &failed}, []string{"b.abyss"}},
+		{"not uploaded", Filter{Tier: AnyTier, Upload: &notUploaded}, []string{"c.abyss", "d.abyss"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filenames(FilterRecordings(testRecordings(), tt.filter)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterRecordings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortRecordings(t *testing.T) {
+	tests := []struct {
+		by      string
+		reverse bool
+		want    []string
+		wantErr bool
+	}{
+		{by: SortByDate, reverse: true, want: []string{"d.abyss", "c.abyss", "b.abyss", "a.abyss"}},
+		{by: SortByDuration, want: []string{"d.abyss", "b.abyss", "c.abyss", "a.abyss"}},
+		{by: SortByTier, reverse: true, want: []string{"a.abyss", "b.abyss", "d.abyss", "c.abyss"}},
+		{by: "price", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			recordings := testRecordings()
+
+			err := SortRecordings(recordings, tt.by, tt.reverse)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SortRecordings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := filenames(recordings); !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortRecordings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	want := Stats{
+		Runs:            4,
+		Completed:       2,
+		SuccessRate:     0.5,
+		AverageDuration: 15 * time.Minute,
+		Uploaded:        1,
+		UploadFailed:    1,
+		Unreadable:      1,
+		RunsPerDay:      []DayRuns{{Day: "2023-05-01", Runs: 2}, {Day: "2023-05-02", Runs: 2}},
+	}
+
+	if got := Summarize(testRecordings()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Summarize() = %+v, want %+v", got, want)
+	}
+}
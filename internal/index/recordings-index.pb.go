@@ -0,0 +1,637 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: recordings-index.proto
+
+package index
+
+import (
+	encoding "github.com/shivas/abyss-blackbox/pkg/encoding"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type IndexedRecording_UploadStatus int32
+
+const (
+	IndexedRecording_NOT_UPLOADED  IndexedRecording_UploadStatus = 0
+	IndexedRecording_UPLOADED      IndexedRecording_UploadStatus = 1
+	IndexedRecording_UPLOAD_FAILED IndexedRecording_UploadStatus = 2
+	// UPLOAD_PENDING recording is in upload queue, upload_error holds error of last attempt.
+	IndexedRecording_UPLOAD_PENDING IndexedRecording_UploadStatus = 3
+	// UPLOAD_UNKNOWN recording was found by folder scan, not saved by recorder since index exists. It might have been
+	// uploaded before, bulk upload skips it.
+	IndexedRecording_UPLOAD_UNKNOWN IndexedRecording_UploadStatus = 4
+)
+
+// Enum value maps for IndexedRecording_UploadStatus.
+var (
+	IndexedRecording_UploadStatus_name = map[int32]string{
+		0: "NOT_UPLOADED",
+		1: "UPLOADED",
+		2: "UPLOAD_FAILED",
+		3: "UPLOAD_PENDING",
+		4: "UPLOAD_UNKNOWN",
+	}
+	IndexedRecording_UploadStatus_value = map[string]int32{
+		"NOT_UPLOADED":   0,
+		"UPLOADED":       1,
+		"UPLOAD_FAILED":  2,
+		"UPLOAD_PENDING": 3,
+		"UPLOAD_UNKNOWN": 4,
+	}
+)
+
+func (x IndexedRecording_UploadStatus) Enum() *IndexedRecording_UploadStatus {
+	p := new(IndexedRecording_UploadStatus)
+	*p = x
+	return p
+}
+
+func (x IndexedRecording_UploadStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IndexedRecording_UploadStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_recordings_index_proto_enumTypes[0].Descriptor()
+}
+
+func (IndexedRecording_UploadStatus) Type() protoreflect.EnumType {
+	return &file_recordings_index_proto_enumTypes[0]
+}
+
+func (x IndexedRecording_UploadStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IndexedRecording_UploadStatus.Descriptor instead.
+func (IndexedRecording_UploadStatus) EnumDescriptor() ([]byte, []int) {
+	return file_recordings_index_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type RecordingsIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Recordings []*IndexedRecording `protobuf:"bytes,1,rep,name=recordings,proto3" json:"recordings,omitempty"`
+	Version    int32               `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *RecordingsIndex) Reset() {
+	*x = RecordingsIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recordings_index_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordingsIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordingsIndex) ProtoMessage() {}
+
+func (x *RecordingsIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_recordings_index_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordingsIndex.ProtoReflect.Descriptor instead.
+func (*RecordingsIndex) Descriptor() ([]byte, []int) {
+	return file_recordings_index_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RecordingsIndex) GetRecordings() []*IndexedRecording {
+	if x != nil {
+		return x.Recordings
+	}
+	return nil
+}
+
+func (x *RecordingsIndex) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type IndexedRecording struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// filename is name of .abyss file inside recordings folder.
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// size and mod_time (unix milliseconds) of file when it was indexed, file is indexed again when they change.
+	Size    int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ModTime int64 `protobuf:"varint,3,opt,name=mod_time,json=modTime,proto3" json:"mod_time,omitempty"`
+	// recorded_at is time recording started in unix milliseconds.
+	RecordedAt              int64                                 `protobuf:"varint,4,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	DurationMs              int64                                 `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Characters              []string                              `protobuf:"bytes,6,rep,name=characters,proto3" json:"characters,omitempty"`
+	Fittings                []*IndexedFit                         `protobuf:"bytes,7,rep,name=fittings,proto3" json:"fittings,omitempty"`
+	TestServer              bool                                  `protobuf:"varint,8,opt,name=test_server,json=testServer,proto3" json:"test_server,omitempty"`
+	ManualAbyssTypeOverride bool                                  `protobuf:"varint,9,opt,name=manual_abyss_type_override,json=manualAbyssTypeOverride,proto3" json:"manual_abyss_type_override,omitempty"`
+	AbyssShipType           encoding.AbyssRecording_AbyssShipType `protobuf:"varint,10,opt,name=abyss_ship_type,json=abyssShipType,proto3,enum=protobuf.AbyssRecording_AbyssShipType" json:"abyss_ship_type,omitempty"`
+	AbyssTier               int32                                 `protobuf:"varint,11,opt,name=abyss_tier,json=abyssTier,proto3" json:"abyss_tier,omitempty"`
+	AbyssWeather            string                                `protobuf:"bytes,12,opt,name=abyss_weather,json=abyssWeather,proto3" json:"abyss_weather,omitempty"`
+	WeatherStrength         int32                                 `protobuf:"varint,13,opt,name=weather_strength,json=weatherStrength,proto3" json:"weather_strength,omitempty"`
+	LootRecordDiscriminator string                                `protobuf:"bytes,14,opt,name=loot_record_discriminator,json=lootRecordDiscriminator,proto3" json:"loot_record_discriminator,omitempty"`
+	RecorderVersion         string                                `protobuf:"bytes,15,opt,name=recorder_version,json=recorderVersion,proto3" json:"recorder_version,omitempty"`
+	UploadStatus            IndexedRecording_UploadStatus         `protobuf:"varint,16,opt,name=upload_status,json=uploadStatus,proto3,enum=protobuf.IndexedRecording_UploadStatus" json:"upload_status,omitempty"`
+	// uploaded_at is time of successful upload in unix milliseconds.
+	UploadedAt  int64  `protobuf:"varint,17,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	UploadError string `protobuf:"bytes,18,opt,name=upload_error,json=uploadError,proto3" json:"upload_error,omitempty"`
+	// error is set when file could not be decoded, other metadata is then missing.
+	Error string `protobuf:"bytes,19,opt,name=error,proto3" json:"error,omitempty"`
+	// last_attempt_at is time of last upload attempt in unix milliseconds, successful or not.
+	LastAttemptAt int64 `protobuf:"varint,20,opt,name=last_attempt_at,json=lastAttemptAt,proto3" json:"last_attempt_at,omitempty"`
+	// loot_records is count of loot snapshots, run with loot captured after initial cargo counts as completed.
+	LootRecords int32 `protobuf:"varint,21,opt,name=loot_records,json=lootRecords,proto3" json:"loot_records,omitempty"`
+	// detected_filament is filament abyss type was detected from by recorder, abyss type fields are set when either
+	// it or manual_abyss_type_override is set.
+	DetectedFilament string `protobuf:"bytes,22,opt,name=detected_filament,json=detectedFilament,proto3" json:"detected_filament,omitempty"`
+}
+
+func (x *IndexedRecording) Reset() {
+	*x = IndexedRecording{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recordings_index_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexedRecording) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexedRecording) ProtoMessage() {}
+
+func (x *IndexedRecording) ProtoReflect() protoreflect.Message {
+	mi := &file_recordings_index_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexedRecording.ProtoReflect.Descriptor instead.
+func (*IndexedRecording) Descriptor() ([]byte, []int) {
+	return file_recordings_index_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IndexedRecording) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *IndexedRecording) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *IndexedRecording) GetModTime() int64 {
+	if x != nil {
+		return x.ModTime
+	}
+	return 0
+}
+
+func (x *IndexedRecording) GetRecordedAt() int64 {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return 0
+}
+
+func (x *IndexedRecording) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *IndexedRecording) GetCharacters() []string {
+	if x != nil {
+		return x.Characters
+	}
+	return nil
+}
+
+func (x *IndexedRecording) GetFittings() []*IndexedFit {
+	if x != nil {
+		return x.Fittings
+	}
+	return nil
+}
+
+func (x *IndexedRecording) GetTestServer() bool {
+	if x != nil {
+		return x.TestServer
+	}
+	return false
+}
+
+func (x *IndexedRecording) GetManualAbyssTypeOverride() bool {
+	if x != nil {
+		return x.ManualAbyssTypeOverride
+	}
+	return false
+}
+
+func (x *IndexedRecording) GetAbyssShipType() encoding.AbyssRecording_AbyssShipType {
+	if x != nil {
+		return x.AbyssShipType
+	}
+	return encoding.AbyssRecording_AbyssShipType(0)
+}
+
+func (x *IndexedRecording) GetAbyssTier() int32 {
+	if x != nil {
+		return x.AbyssTier
+	}
+	return 0
+}
+
+func (x *IndexedRecording) GetAbyssWeather() string {
+	if x != nil {
+		return x.AbyssWeather
+	}
+	return ""
+}
+
+func (x *IndexedRecording) GetWeatherStrength() int32 {
+	if x != nil {
+		return x.WeatherStrength
+	}
+	return 0
+}
+
+func (x *IndexedRecording) GetLootRecordDiscriminator() string {
+	if x != nil {
+		return x.LootRecordDiscriminator
+	}
+	return ""
+}
+
+func (x *IndexedRecording) GetRecorderVersion() string {
+	if x != nil {
+		return x.RecorderVersion
+	}
+	return ""
+}
+
+func (x *IndexedRecording) GetUploadStatus() IndexedRecording_UploadStatus {
+	if x != nil {
+		return x.UploadStatus
+	}
+	return IndexedRecording_NOT_UPLOADED
+}
+
+func (x *IndexedRecording) GetUploadedAt() int64 {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return 0
+}
+
+func (x *IndexedRecording) GetUploadError() string {
+	if x != nil {
+		return x.UploadError
+	}
+	return ""
+}
+
+func (x *IndexedRecording) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *IndexedRecording) GetLastAttemptAt() int64 {
+	if x != nil {
+		return x.LastAttemptAt
+	}
+	return 0
+}
+
+func (x *IndexedRecording) GetLootRecords() int32 {
+	if x != nil {
+		return x.LootRecords
+	}
+	return 0
+}
+
+func (x *IndexedRecording) GetDetectedFilament() string {
+	if x != nil {
+		return x.DetectedFilament
+	}
+	return ""
+}
+
+type IndexedFit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Character   string  `protobuf:"bytes,1,opt,name=character,proto3" json:"character,omitempty"`
+	FittingName string  `protobuf:"bytes,2,opt,name=fittingName,proto3" json:"fittingName,omitempty"`
+	ShipName    string  `protobuf:"bytes,3,opt,name=shipName,proto3" json:"shipName,omitempty"`
+	ShipTypeID  int32   `protobuf:"varint,4,opt,name=shipTypeID,proto3" json:"shipTypeID,omitempty"`
+	FFH         string  `protobuf:"bytes,5,opt,name=FFH,proto3" json:"FFH,omitempty"`
+	Price       float64 `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+}
+
+func (x *IndexedFit) Reset() {
+	*x = IndexedFit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recordings_index_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexedFit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexedFit) ProtoMessage() {}
+
+func (x *IndexedFit) ProtoReflect() protoreflect.Message {
+	mi := &file_recordings_index_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexedFit.ProtoReflect.Descriptor instead.
+func (*IndexedFit) Descriptor() ([]byte, []int) {
+	return file_recordings_index_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IndexedFit) GetCharacter() string {
+	if x != nil {
+		return x.Character
+	}
+	return ""
+}
+
+func (x *IndexedFit) GetFittingName() string {
+	if x != nil {
+		return x.FittingName
+	}
+	return ""
+}
+
+func (x *IndexedFit) GetShipName() string {
+	if x != nil {
+		return x.ShipName
+	}
+	return ""
+}
+
+func (x *IndexedFit) GetShipTypeID() int32 {
+	if x != nil {
+		return x.ShipTypeID
+	}
+	return 0
+}
+
+func (x *IndexedFit) GetFFH() string {
+	if x != nil {
+		return x.FFH
+	}
+	return ""
+}
+
+func (x *IndexedFit) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+var File_recordings_index_proto protoreflect.FileDescriptor
+
+var file_recordings_index_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2d, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x1a, 0x0f, 0x61, 0x62, 0x79, 0x73, 0x73, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x67, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x3a, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x80, 0x08, 0x0a,
+	0x10, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x6f, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x73, 0x12, 0x30,
+	0x0a, 0x08, 0x66, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x65, 0x64, 0x46, 0x69, 0x74, 0x52, 0x08, 0x66, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x74, 0x65, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x12, 0x3b, 0x0a, 0x1a, 0x6d, 0x61, 0x6e, 0x75, 0x61, 0x6c, 0x5f, 0x61, 0x62, 0x79, 0x73,
+	0x73, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x6d, 0x61, 0x6e, 0x75, 0x61, 0x6c, 0x41, 0x62, 0x79,
+	0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x4e,
+	0x0a, 0x0f, 0x61, 0x62, 0x79, 0x73, 0x73, 0x5f, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x41, 0x62, 0x79, 0x73, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x2e, 0x41, 0x62, 0x79, 0x73, 0x73, 0x53, 0x68, 0x69, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x0d, 0x61, 0x62, 0x79, 0x73, 0x73, 0x53, 0x68, 0x69, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x61, 0x62, 0x79, 0x73, 0x73, 0x5f, 0x74, 0x69, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x61, 0x62, 0x79, 0x73, 0x73, 0x54, 0x69, 0x65, 0x72, 0x12, 0x23, 0x0a,
+	0x0d, 0x61, 0x62, 0x79, 0x73, 0x73, 0x5f, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x62, 0x79, 0x73, 0x73, 0x57, 0x65, 0x61, 0x74, 0x68,
+	0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x73, 0x74,
+	0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x77, 0x65,
+	0x61, 0x74, 0x68, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x3a, 0x0a,
+	0x19, 0x6c, 0x6f, 0x6f, 0x74, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x64, 0x69, 0x73,
+	0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x17, 0x6c, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44, 0x69, 0x73, 0x63,
+	0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4c, 0x0a, 0x0d, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x0c, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0f,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x5f, 0x61, 0x74, 0x18,
+	0x14, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x6f, 0x6f, 0x74, 0x5f, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6c, 0x6f, 0x6f, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x65, 0x74, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x61,
+	0x6d, 0x65, 0x6e, 0x74, 0x22, 0x69, 0x0a, 0x0c, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x0c, 0x4e, 0x4f, 0x54, 0x5f, 0x55, 0x50, 0x4c, 0x4f,
+	0x41, 0x44, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x55, 0x50, 0x4c, 0x4f, 0x41, 0x44,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x50, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x46,
+	0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x50, 0x4c, 0x4f, 0x41,
+	0x44, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x55,
+	0x50, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x04, 0x22,
+	0xb0, 0x01, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x46, 0x69, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b,
+	0x66, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x66, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x68, 0x69, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x68, 0x69, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x68,
+	0x69, 0x70, 0x54, 0x79, 0x70, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x73, 0x68, 0x69, 0x70, 0x54, 0x79, 0x70, 0x65, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x46, 0x46,
+	0x48, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x46, 0x46, 0x48, 0x12, 0x14, 0x0a, 0x05,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x73, 0x68, 0x69, 0x76, 0x61, 0x73, 0x2f, 0x61, 0x62, 0x79, 0x73, 0x73, 0x2d, 0x62, 0x6c,
+	0x61, 0x63, 0x6b, 0x62, 0x6f, 0x78, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_recordings_index_proto_rawDescOnce sync.Once
+	file_recordings_index_proto_rawDescData = file_recordings_index_proto_rawDesc
+)
+
+func file_recordings_index_proto_rawDescGZIP() []byte {
+	file_recordings_index_proto_rawDescOnce.Do(func() {
+		file_recordings_index_proto_rawDescData = protoimpl.X.CompressGZIP(file_recordings_index_proto_rawDescData)
+	})
+	return file_recordings_index_proto_rawDescData
+}
+
+var file_recordings_index_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_recordings_index_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_recordings_index_proto_goTypes = []interface{}{
+	(IndexedRecording_UploadStatus)(0),         // 0: protobuf.IndexedRecording.UploadStatus
+	(*RecordingsIndex)(nil),                    // 1: protobuf.RecordingsIndex
+	(*IndexedRecording)(nil),                   // 2: protobuf.IndexedRecording
+	(*IndexedFit)(nil),                         // 3: protobuf.IndexedFit
+	(encoding.AbyssRecording_AbyssShipType)(0), // 4: protobuf.AbyssRecording.AbyssShipType
+}
+var file_recordings_index_proto_depIdxs = []int32{
+	2, // 0: protobuf.RecordingsIndex.recordings:type_name -> protobuf.IndexedRecording
+	3, // 1: protobuf.IndexedRecording.fittings:type_name -> protobuf.IndexedFit
+	4, // 2: protobuf.IndexedRecording.abyss_ship_type:type_name -> protobuf.AbyssRecording.AbyssShipType
+	0, // 3: protobuf.IndexedRecording.upload_status:type_name -> protobuf.IndexedRecording.UploadStatus
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_recordings_index_proto_init() }
+func file_recordings_index_proto_init() {
+	if File_recordings_index_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_recordings_index_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordingsIndex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recordings_index_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexedRecording); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recordings_index_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexedFit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_recordings_index_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_recordings_index_proto_goTypes,
+		DependencyIndexes: file_recordings_index_proto_depIdxs,
+		EnumInfos:         file_recordings_index_proto_enumTypes,
+		MessageInfos:      file_recordings_index_proto_msgTypes,
+	}.Build()
+	File_recordings_index_proto = out.File
+	file_recordings_index_proto_rawDesc = nil
+	file_recordings_index_proto_goTypes = nil
+	file_recordings_index_proto_depIdxs = nil
+}
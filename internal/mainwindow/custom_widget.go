@@ -10,6 +10,7 @@ import (
 	"github.com/disintegration/imaging"
 	"github.com/lxn/walk"
 	"github.com/lxn/win"
+	"github.com/shivas/abyss-blackbox/internal/app/domain"
 	"github.com/shivas/abyss-blackbox/internal/config"
 	"github.com/shivas/abyss-blackbox/internal/screen"
 )
@@ -20,7 +21,7 @@ func CustomWidgetDrawLoop(
 	capturer screen.ScreenCapturer,
 	currentSettings *config.CaptureConfig,
 	previewChannel chan image.Image,
-	recordingChannel chan *image.Paletted,
+	recordingChannel chan domain.Frame,
 
 ) {
 	t := time.NewTicker(time.Second)
@@ -28,6 +29,7 @@ func CustomWidgetDrawLoop(
 
 	for range t.C {
 		img, err := capturer.CaptureWindowArea()
+		capturedAt := time.Now()
 		if err != nil {
 			walk.MsgBox(mw, "Error capturing window area, restart of application is needed.", err.Error(), walk.MsgBoxIconWarning)
 			slog.Error("error lost capture window: %v", err)
@@ -53,7 +55,7 @@ func CustomWidgetDrawLoop(
 		}
 
 		select {
-		case recordingChannel <- img3:
+		case recordingChannel <- domain.Frame{Image: img3, CapturedAt: capturedAt}:
 		default:
 			slog.Debug("recorder channel is full, dropping frame")
 		}
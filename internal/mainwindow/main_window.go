@@ -47,6 +47,7 @@ type AbyssRecorderWindow struct {
 	Toolbar                *walk.ToolBar
 	AutoUploadCheckbox     *walk.CheckBox
 	SettingsAction         *walk.Action
+	UploadRecordingsAction *walk.Action
 	PresetSwitcherMenu     *walk.Menu
 	PresetSaveButton       *walk.PushButton
 	PreviewScrollView      *walk.ScrollView
@@ -142,6 +143,10 @@ func NewAbyssRecorderWindow(
 				AssignTo: &obj.SettingsAction,
 				Text:     "Settings",
 			},
+			Action{
+				AssignTo: &obj.UploadRecordingsAction,
+				Text:     "Upload recordings",
+			},
 			Action{
 				Text: "About",
 				OnTriggered: func() {
@@ -491,7 +496,7 @@ func NewAbyssRecorderWindow(
 // DrawStuff returns draw function main window preview custom widget.
 func WidgetDrawFn(
 	previewChannel chan image.Image,
-	recordingChannel chan *image.Paletted,
+	recordingChannel chan domain.Frame,
 ) walk.PaintFunc {
 	return func(canvas *walk.Canvas, updateBounds walk.Rectangle) error {
 		select {
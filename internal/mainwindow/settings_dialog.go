@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/shivas/abyss-blackbox/internal/config"
+	"github.com/shivas/abyss-blackbox/pkg/inventory"
 
 	"github.com/lxn/walk"
 	. "github.com/lxn/walk/declarative" //nolint:stylecheck,revive // we needs side effects
@@ -29,6 +30,8 @@ func RunSettingsDialog(owner walk.Form, conf interface{}, onSettingsSubmit func(
 		OverlayShortcutEdit           *walk.LineEdit
 		OverlayShortcutRecordButton   *walk.PushButton
 		LootRecordDiscriminatorEdit   *walk.LineEdit
+		DiscriminatorQuantitiesEdit   *walk.LineEdit
+		PricesFileEdit                *walk.LineEdit
 		EVEGameLogsFolderLabel        *walk.TextLabel
 		ChooseLogDirButton            *walk.PushButton
 	)
@@ -106,6 +109,13 @@ func RunSettingsDialog(owner walk.Form, conf interface{}, onSettingsSubmit func(
 					TextLabel{
 						Text: "Only used when multiboxing destroyer or frigate runs.",
 					},
+					TextLabel{
+						Text: "Discriminator quantity carried by each character: (Character=1, Other Character=2)",
+					},
+					LineEdit{
+						Text:     Bind("LootRecordDiscriminatorQuantities"),
+						AssignTo: &DiscriminatorQuantitiesEdit,
+					},
 				},
 			},
 			GroupBox{
@@ -303,6 +313,72 @@ func RunSettingsDialog(owner walk.Form, conf interface{}, onSettingsSubmit func(
 					},
 				},
 			},
+			GroupBox{
+				Title:     "Recording",
+				Layout:    VBox{},
+				Alignment: AlignHNearVNear,
+				Children: []Widget{
+					CheckBox{
+						Text:        "Store overview as compact frames instead of GIF",
+						ToolTipText: "Recordings get much smaller, but older tools might not understand them (uploads are converted to GIF)",
+						Checked:     Bind("CompactOverview"),
+					},
+					CheckBox{
+						Text:        "Record any clipboard text as loot",
+						ToolTipText: "By default only inventory copies are recorded, so passwords, chat or links copied during run never end up in recording",
+						Checked:     Bind("RecordAnyClipboard"),
+					},
+					Composite{
+						Layout:    HBox{MarginsZero: true},
+						Alignment: AlignHNearVNear,
+						Children: []Widget{
+							TextLabel{
+								Text: "Compression",
+							},
+							ComboBox{
+								Model:       []string{"gzip", "zstd"},
+								ToolTipText: "zstd compresses and opens recordings faster, but older tools can't read it (uploads are converted to gzip)",
+								Value:       Bind("RecordingCodec"),
+								Editable:    false,
+							},
+						},
+					},
+				},
+			},
+			GroupBox{
+				Title:     "Loot and fitting prices",
+				Layout:    VBox{},
+				Alignment: AlignHNearVNear,
+				Children: []Widget{
+					TextLabel{
+						Text: "Price file: (CSV or JSON with name, price and optional typeID)",
+					},
+					Composite{
+						Layout: HBox{MarginsZero: true},
+						Children: []Widget{
+							LineEdit{
+								Text:     Bind("PricesFile"),
+								AssignTo: &PricesFileEdit,
+							},
+							PushButton{
+								Text: "Choose",
+								OnClicked: func() {
+									fd := walk.FileDialog{Filter: "Price files (*.csv;*.json)|*.csv;*.json"}
+									if accepted, _ := fd.ShowOpen(owner); accepted {
+										_ = PricesFileEdit.SetText(fd.FilePath)
+									}
+								},
+							},
+						},
+					},
+					TextLabel{
+						Text: "Price service URL: (asked for items missing in price file)",
+					},
+					LineEdit{
+						Text: Bind("PricesURL"),
+					},
+				},
+			},
 			Composite{
 				Layout: HBox{},
 				Children: []Widget{
@@ -311,6 +387,11 @@ func RunSettingsDialog(owner walk.Form, conf interface{}, onSettingsSubmit func(
 						AssignTo: &acceptPB,
 						Text:     "OK",
 						OnClicked: func() {
+							if _, err := inventory.ParseDiscriminatorQuantities(DiscriminatorQuantitiesEdit.Text()); err != nil {
+								walk.MsgBox(dlg, "Invalid loot recording settings", err.Error(), walk.MsgBoxIconWarning)
+								return
+							}
+
 							if err := db.Submit(); err != nil {
 								log.Print(err)
 								return
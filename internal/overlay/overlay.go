@@ -33,6 +33,7 @@ const (
 	Weather    WidgetProperty = "weather"
 	TODO       WidgetProperty = "todo"
 	Override   WidgetProperty = "override"
+	Value      WidgetProperty = "value"
 	Autoupload WidgetProperty = "autoupload"
 )
 
@@ -62,6 +63,7 @@ func New(c *OverlayConfig, captureConfig *config.CaptureConfig) *Overlay {
 				Weather:    {text: ""},
 				TODO:       {text: "Long text message can be here"},
 				Override:   {text: "Manual override text", color: &CyanColor},
+				Value:      {text: ""},
 				Autoupload: {text: "Autoupload status", color: &CyanColor},
 			},
 		},
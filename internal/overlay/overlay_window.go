@@ -111,6 +111,11 @@ func (o *OverlayDialog) drawStuff(canvas *walk.Canvas, updateBounds walk.Rectang
 		return err
 	}
 
+	bounds.Y += o.config.Spacing
+	if err := canvas.DrawTextPixels(o.state.items[Value].text, font, lineColor(o.state.items[Value], o.config.Color), bounds, walk.TextWordbreak); err != nil {
+		return err
+	}
+
 	bounds.Y += o.config.Spacing
 	if err := canvas.DrawTextPixels(o.state.items[Autoupload].text, font, lineColor(o.state.items[Autoupload], o.config.Color), bounds, walk.TextWordbreak); err != nil {
 		return err
@@ -0,0 +1,78 @@
+package uploader
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// DefaultConcurrency is how many recordings bulk upload sends at once.
+const DefaultConcurrency = 3
+
+// Progress of bulk upload, reported after every finished upload.
+type Progress struct {
+	Done   int
+	Total  int
+	Failed int
+	// Result of upload just finished.
+	Result Result
+}
+
+// UploadAll uploads recordings, at most concurrency of them at once, and returns results in order of filenames.
+// Uploads not started yet are skipped when ctx is cancelled, their results carry ctx error.
+func UploadAll(ctx context.Context, client *http.Client, filenames []string, concurrency int, progress func(Progress)) []Result {
+	return uploadAll(ctx, client, ingestURL, filenames, concurrency, progress)
+}
+
+func uploadAll(ctx context.Context, client *http.Client, url string, filenames []string, concurrency int, progress func(Progress)) []Result {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]Result, len(filenames))
+	p := Progress{Total: len(filenames)}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	slots := make(chan struct{}, concurrency)
+
+	for i, filename := range filenames {
+		wg.Add(1)
+
+		go func(i int, filename string) {
+			defer wg.Done()
+
+			result := Result{Filename: filename, Attempts: 1}
+
+			select {
+			case slots <- struct{}{}:
+				result.Response, result.Err = upload(ctx, client, url, filename)
+				<-slots
+			case <-ctx.Done():
+				result.Err, result.Attempts = ctx.Err(), 0
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			results[i] = result
+			p.Done++
+			p.Result = result
+
+			if result.Err != nil {
+				p.Failed++
+			}
+
+			if progress != nil {
+				progress(p)
+			}
+		}(i, filename)
+	}
+
+	wg.Wait()
+
+	return results
+}
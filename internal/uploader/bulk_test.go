@@ -0,0 +1,79 @@
+package uploader
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+func TestUploadAll(t *testing.T) {
+	var (
+		mu                sync.Mutex
+		inFlight, maxSeen int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxSeen = max(maxSeen, inFlight)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+
+	var filenames []string
+
+	for _, name := range []string{"a.abyss", "b.abyss", "missing.abyss", "c.abyss", "d.abyss", "e.abyss"} {
+		filename := filepath.Join(dir, name)
+		filenames = append(filenames, filename)
+
+		if name == "missing.abyss" {
+			continue
+		}
+
+		writeRecording(t, filename, &encoding.AbyssRecording{RecorderVersion: name})
+	}
+
+	var reports []Progress
+
+	results := uploadAll(context.Background(), server.Client(), server.URL, filenames, 2, func(p Progress) {
+		reports = append(reports, p)
+	})
+
+	if maxSeen > 2 {
+		t.Errorf("%d uploads in flight, want at most 2", maxSeen)
+	}
+
+	for i, result := range results {
+		if result.Filename != filenames[i] {
+			t.Errorf("result %d is of %s, want %s", i, result.Filename, filenames[i])
+		}
+
+		if (result.Err != nil) != (i == 2) {
+			t.Errorf("result of %s error = %v", result.Filename, result.Err)
+		}
+	}
+
+	if len(reports) != len(filenames) {
+		t.Fatalf("%d progress reports, want %d", len(reports), len(filenames))
+	}
+
+	if last := reports[len(reports)-1]; last.Done != 6 || last.Total != 6 || last.Failed != 1 {
+		t.Errorf("last progress = %+v", last)
+	}
+}
@@ -0,0 +1,400 @@
+package uploader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is how many times upload is attempted before queue gives up on it.
+	DefaultMaxAttempts = 10
+	// DefaultBackoff is delay before first retry, doubled with every following failure up to DefaultMaxBackoff.
+	DefaultBackoff    = 30 * time.Second
+	DefaultMaxBackoff = time.Hour
+)
+
+// Status is upload status of queued recording.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusRetrying Status = "retrying"
+	// StatusFailed recording won't be attempted again unless retried manually.
+	StatusFailed Status = "failed"
+)
+
+// Item is recording waiting in upload queue.
+type Item struct {
+	Filename    string    `json:"filename"`
+	Status      Status    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	QueuedAt    time.Time `json:"queuedAt"`
+	NextAttempt time.Time `json:"nextAttempt"`
+	LastError   string    `json:"lastError,omitempty"`
+}
+
+// Result is outcome of single upload attempt.
+type Result struct {
+	Filename string
+	// Response of ingest endpoint, set when upload succeeded.
+	Response string
+	Err      error
+	Attempts int
+	// NextAttempt is set when failed upload will be retried.
+	NextAttempt time.Time
+}
+
+// Retrying tells whether failed upload stays queued.
+func (r Result) Retrying() bool {
+	return r.Err != nil && !r.NextAttempt.IsZero()
+}
+
+// QueueOption configures upload queue.
+type QueueOption func(*Queue)
+
+// WithURL sets ingest endpoint recordings are uploaded to.
+func WithURL(url string) QueueOption {
+	return func(q *Queue) {
+		q.url = url
+	}
+}
+
+// WithBackoff sets delay before first retry and maximum delay between retries.
+func WithBackoff(initial, max time.Duration) QueueOption {
+	return func(q *Queue) {
+		q.backoff, q.maxBackoff = initial, max
+	}
+}
+
+// WithMaxAttempts sets how many times upload is attempted before queue gives up on it.
+func WithMaxAttempts(attempts int) QueueOption {
+	return func(q *Queue) {
+		q.maxAttempts = attempts
+	}
+}
+
+// Queue uploads recordings in background, retrying failed uploads with exponential backoff.
+// Queued recordings are stored in state file, so they survive restart of recorder.
+type Queue struct {
+	client      *http.Client
+	stateFile   string
+	url         string
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	maxAttempts int
+	now         func() time.Time
+
+	mu    sync.Mutex
+	items []*Item
+
+	wake     chan struct{}
+	started  bool // guarded by mu
+	stopOnce sync.Once
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+// NewQueue creates upload queue, loading recordings queued before from state file.
+func NewQueue(client *http.Client, stateFile string, opts ...QueueOption) (*Queue, error) {
+	q := &Queue{
+		client:      client,
+		stateFile:   stateFile,
+		url:         ingestURL,
+		backoff:     DefaultBackoff,
+		maxBackoff:  DefaultMaxBackoff,
+		maxAttempts: DefaultMaxAttempts,
+		now:         time.Now,
+		wake:        make(chan struct{}, 1),
+		done:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(q)
+	}
+
+	data, err := os.ReadFile(stateFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return q, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, &q.items); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// Enqueue adds recording to queue, recording already queued is attempted again right away.
+func (q *Queue) Enqueue(filename string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+
+	if item := q.find(filename); item != nil {
+		item.Status, item.Attempts, item.NextAttempt = StatusPending, 0, now
+	} else {
+		q.items = append(q.items, &Item{Filename: filename, Status: StatusPending, QueuedAt: now, NextAttempt: now})
+	}
+
+	q.notify()
+
+	return q.save()
+}
+
+// Remove removes recording from queue.
+func (q *Queue) Remove(filename string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, item := range q.items {
+		if item.Filename == filename {
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			return q.save()
+		}
+	}
+
+	return nil
+}
+
+// Status returns state of queued recording, false when recording is not queued (never queued or already uploaded).
+func (q *Queue) Status(filename string) (Item, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if item := q.find(filename); item != nil {
+		return *item, true
+	}
+
+	return Item{}, false
+}
+
+// Items returns recordings in queue, in order they were queued.
+func (q *Queue) Items() []Item {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	items := make([]Item, 0, len(q.items))
+	for _, item := range q.items {
+		items = append(items, *item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].QueuedAt.Before(items[j].QueuedAt) })
+
+	return items
+}
+
+// Start starts uploading in background, onResult is called after every upload attempt.
+func (q *Queue) Start(onResult func(Result)) {
+	q.mu.Lock()
+	q.started = true
+	q.mu.Unlock()
+
+	go q.run(onResult)
+}
+
+// Stop stops uploading, waiting for upload in progress to finish. Unfinished uploads stay queued.
+// Stopping stopped queue does nothing.
+func (q *Queue) Stop() {
+	q.stopOnce.Do(func() { close(q.done) })
+
+	q.mu.Lock()
+	started := q.started
+	q.mu.Unlock()
+
+	if started {
+		<-q.stopped
+	}
+}
+
+func (q *Queue) run(onResult func(Result)) {
+	defer close(q.stopped)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-q.done
+		cancel()
+	}()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		item, wait := q.next()
+
+		if item != nil {
+			result := q.attempt(ctx, item)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if onResult != nil {
+				onResult(result)
+			}
+
+			continue
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+
+		if wait > 0 {
+			timer.Reset(wait)
+		}
+
+		select {
+		case <-q.done:
+			return
+		case <-q.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// next returns copy of item due for upload, or how long to wait for next one (zero when queue is idle).
+func (q *Queue) next() (*Item, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var (
+		due  *Item
+		wait time.Duration
+	)
+
+	now := q.now()
+
+	for _, item := range q.items {
+		if item.Status == StatusFailed {
+			continue
+		}
+
+		if !item.NextAttempt.After(now) {
+			if due == nil || item.NextAttempt.Before(due.NextAttempt) {
+				due = item
+			}
+
+			continue
+		}
+
+		if d := item.NextAttempt.Sub(now); wait == 0 || d < wait {
+			wait = d
+		}
+	}
+
+	if due == nil {
+		return nil, wait
+	}
+
+	item := *due
+
+	return &item, 0
+}
+
+func (q *Queue) attempt(ctx context.Context, item *Item) Result {
+	response, err := upload(ctx, q.client, q.url, item.Filename)
+	if ctx.Err() != nil {
+		return Result{} // stopping, item stays queued
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	result := Result{Filename: item.Filename, Response: response, Err: err, Attempts: item.Attempts + 1}
+
+	queued := q.find(item.Filename)
+	if queued == nil {
+		return result // removed meanwhile
+	}
+
+	if err == nil {
+		for i := range q.items {
+			if q.items[i] == queued {
+				q.items = append(q.items[:i], q.items[i+1:]...)
+				break
+			}
+		}
+
+		_ = q.save()
+
+		return result
+	}
+
+	queued.Attempts++
+	queued.LastError = err.Error()
+
+	if !retryable(err) || queued.Attempts >= q.maxAttempts {
+		queued.Status = StatusFailed
+	} else {
+		queued.Status = StatusRetrying
+		queued.NextAttempt = q.now().Add(q.delay(queued.Attempts))
+		result.NextAttempt = queued.NextAttempt
+	}
+
+	_ = q.save()
+
+	return result
+}
+
+// delay returns delay before next attempt after given number of failed attempts.
+func (q *Queue) delay(attempts int) time.Duration {
+	d := q.backoff
+
+	for i := 1; i < attempts && d < q.maxBackoff; i++ {
+		d *= 2
+	}
+
+	if d > q.maxBackoff {
+		d = q.maxBackoff
+	}
+
+	return d
+}
+
+func (q *Queue) find(filename string) *Item {
+	for _, item := range q.items {
+		if item.Filename == filename {
+			return item
+		}
+	}
+
+	return nil
+}
+
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// save writes queue to temporary file first, so crash never leaves state file half written.
+func (q *Queue) save() error {
+	data, err := json.MarshalIndent(q.items, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(q.stateFile+".tmp", data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(q.stateFile+".tmp", q.stateFile)
+}
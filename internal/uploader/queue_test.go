@@ -0,0 +1,166 @@
+package uploader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+// ingestStub responds to uploads with statuses in order, repeating last one.
+type ingestStub struct {
+	mu       sync.Mutex
+	statuses []int
+	uploads  int
+}
+
+func (s *ingestStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Header.Get("Content-Type") != "application/abyss-run-record" {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	status := s.statuses[min(s.uploads, len(s.statuses)-1)]
+	s.uploads++
+
+	w.WriteHeader(status)
+}
+
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantAttempts int
+		wantQueued   bool
+		wantStatus   Status
+	}{
+		{name: "uploaded", statuses: []int{http.StatusAccepted}, wantAttempts: 1},
+		{name: "retried until uploaded", statuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusAccepted}, wantAttempts: 3},
+		{name: "rejected", statuses: []int{http.StatusBadRequest}, wantAttempts: 1, wantQueued: true, wantStatus: StatusFailed},
+		{name: "gave up", statuses: []int{http.StatusInternalServerError}, wantAttempts: 4, wantQueued: true, wantStatus: StatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &ingestStub{statuses: tt.statuses}
+			server := httptest.NewServer(stub)
+			defer server.Close()
+
+			dir := t.TempDir()
+			recording := filepath.Join(dir, "run.abyss")
+
+			writeRecording(t, recording, &encoding.AbyssRecording{})
+
+			q, err := NewQueue(server.Client(), filepath.Join(dir, "uploads.json"), WithURL(server.URL), WithBackoff(time.Millisecond, 4*time.Millisecond), WithMaxAttempts(4))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results := make(chan Result, 10)
+			q.Start(func(r Result) { results <- r })
+
+			defer q.Stop()
+
+			if err = q.Enqueue(recording); err != nil {
+				t.Fatal(err)
+			}
+
+			var last Result
+
+			for i := 0; i < tt.wantAttempts; i++ {
+				select {
+				case last = <-results:
+				case <-time.After(5 * time.Second):
+					t.Fatalf("attempt %d not made", i+1)
+				}
+
+				if last.Attempts != i+1 || last.Retrying() != (i+1 < tt.wantAttempts) {
+					t.Fatalf("attempt %d result = %+v", i+1, last)
+				}
+			}
+
+			if (last.Err == nil) == tt.wantQueued {
+				t.Errorf("last result error = %v", last.Err)
+			}
+
+			item, queued := q.Status(recording)
+			if queued != tt.wantQueued || item.Status != tt.wantStatus {
+				t.Errorf("Status() = %+v, %t, want %q, %t", item, queued, tt.wantStatus, tt.wantQueued)
+			}
+		})
+	}
+}
+
+func TestQueue_SurvivesRestart(t *testing.T) {
+	stub := &ingestStub{statuses: []int{http.StatusServiceUnavailable, http.StatusAccepted}}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	dir := t.TempDir()
+	stateFile := filepath.Join(dir, "uploads.json")
+	recording := filepath.Join(dir, "run.abyss")
+
+	writeRecording(t, recording, &encoding.AbyssRecording{})
+
+	q, err := NewQueue(server.Client(), stateFile, WithURL(server.URL), WithBackoff(time.Hour, time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := make(chan Result, 1)
+	q.Start(func(r Result) { results <- r })
+
+	if err = q.Enqueue(recording); err != nil {
+		t.Fatal(err)
+	}
+
+	if r := <-results; !r.Retrying() {
+		t.Fatalf("result = %+v, want retry", r)
+	}
+
+	q.Stop()
+
+	// restarted queue keeps backoff of failed upload, until recording is queued again
+	q, err = NewQueue(server.Client(), stateFile, WithURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, ok := q.Status(recording)
+	if !ok || item.Status != StatusRetrying || item.Attempts != 1 {
+		t.Fatalf("Status() after restart = %+v, %t", item, ok)
+	}
+
+	q.Start(func(r Result) { results <- r })
+	defer q.Stop()
+
+	if err = q.Enqueue(recording); err != nil {
+		t.Fatal(err)
+	}
+
+	if r := <-results; r.Err != nil {
+		t.Fatalf("upload after restart failed: %v", r.Err)
+	}
+
+	if items := q.Items(); len(items) != 0 {
+		t.Errorf("Items() = %+v, want empty queue", items)
+	}
+}
+
+func TestQueue_StopTwice(t *testing.T) {
+	q, err := NewQueue(http.DefaultClient, filepath.Join(t.TempDir(), "uploads.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q.Start(nil)
+	q.Stop()
+	q.Stop()
+}
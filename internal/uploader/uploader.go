@@ -1,28 +1,50 @@
 package uploader
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
 )
 
-const ingestURL = "https://abyssal.space/action/ingest-autoupload"
+const (
+	ingestURL     = "https://abyssal.space/action/ingest-autoupload"
+	uploadTimeout = 20 * time.Second
+)
+
+// StatusError returned when ingest endpoint responds with unexpected status.
+type StatusError struct {
+	Filename   string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("failed uploading: %s, status: %s", e.Filename, e.Status)
+}
 
 func Upload(client *http.Client, filename string) (name string, err error) {
-	f, err := os.Open(filename)
+	return upload(context.Background(), client, ingestURL, filename)
+}
+
+func upload(ctx context.Context, client *http.Client, url, filename string) (name string, err error) {
+	body, err := payload(filename)
 	if err != nil {
 		return filename, err
 	}
 
-	defer f.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*20)
+	ctx, cancel := context.WithTimeout(ctx, uploadTimeout)
 
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, "POST", ingestURL, f)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
 	if err != nil {
 		return filename, err
 	}
@@ -37,8 +59,75 @@ func Upload(client *http.Client, filename string) (name string, err error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusAccepted {
-		return filename, fmt.Errorf("failed uploading: %s, status: %s", filename, resp.Status)
+		return filename, &StatusError{Filename: filename, StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
 	return filename, nil
 }
+
+// payload returns body of upload. Ingest endpoint reads only legacy headerless gzip recordings with GIF overview,
+// recordings in container are converted to it.
+func payload(filename string) (io.Reader, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := encoding.ReadHeader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	if header.Legacy() {
+		return bytes.NewReader(data), nil
+	}
+
+	rec, err := encoding.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	if len(rec.GetOverview()) == 0 && rec.GetOverviewFrames().Len() > 0 {
+		if rec.Overview, err = rec.OverviewGIF(); err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+
+		rec.OverviewFrames = nil
+	}
+
+	var buf bytes.Buffer
+	if err = rec.Encode(&buf, encoding.WithoutHeader()); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+// retryable tells whether upload failing with err might succeed later.
+// Network errors and server side failures are retried, missing file and rejected recording are not.
+func retryable(err error) bool {
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+		return false
+	}
+
+	// recording can't be read, uploading it again won't help
+	if errors.Is(err, encoding.ErrNotAbyssFile) || errors.Is(err, encoding.ErrCorrupted) ||
+		errors.Is(err, encoding.ErrUnsupportedVersion) || errors.Is(err, encoding.ErrUnsupportedCodec) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case statusErr.StatusCode >= http.StatusInternalServerError,
+			statusErr.StatusCode == http.StatusRequestTimeout,
+			statusErr.StatusCode == http.StatusTooManyRequests,
+			statusErr.StatusCode == http.StatusUnauthorized:
+			return true
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,135 @@
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+	"google.golang.org/protobuf/proto"
+)
+
+// writeRecording writes recording to filename, encoded with opts.
+func writeRecording(t *testing.T, filename string, rec *encoding.AbyssRecording, opts ...encoding.EncodeOption) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := rec.Encode(&buf, opts...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filename, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	var body []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rec := &encoding.AbyssRecording{Overview: []byte{1, 2, 3}, RecorderVersion: "test"}
+
+	tests := []struct {
+		name    string
+		opts    []encoding.EncodeOption
+		content []byte
+		wantErr bool
+	}{
+		{name: "container gzip"},
+		{name: "container zstd", opts: []encoding.EncodeOption{encoding.WithCodec(encoding.CodecZstd)}},
+		{name: "legacy", opts: []encoding.EncodeOption{encoding.WithoutHeader()}},
+		{name: "not abyss file", content: []byte("recording"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body = nil
+			filename := filepath.Join(dir, tt.name+".abyss")
+
+			if tt.content != nil {
+				if err := os.WriteFile(filename, tt.content, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				writeRecording(t, filename, rec, tt.opts...)
+			}
+
+			_, err := upload(context.Background(), server.Client(), server.URL, filename)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("upload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				if body != nil || retryable(err) {
+					t.Errorf("unreadable recording posted %d bytes, retryable %t", len(body), retryable(err))
+				}
+
+				return
+			}
+
+			// ingest endpoint reads only legacy gzip recordings
+			if !bytes.HasPrefix(body, []byte{0x1f, 0x8b}) {
+				t.Fatalf("posted body starts with % x, want gzip", body[:min(len(body), 8)])
+			}
+
+			got, err := encoding.Decode(bytes.NewReader(body))
+			if err != nil || !proto.Equal(got, rec) {
+				t.Errorf("posted recording = %v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestUpload_CompactOverview(t *testing.T) {
+	var body []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	encoder := encoding.NewFrameEncoder(encoding.DefaultKeyframeInterval)
+	frame := image.NewPaletted(image.Rect(0, 0, 4, 3), []color.Color{color.White, color.Black})
+
+	for i := 0; i < 3; i++ {
+		frame.SetColorIndex(i, i, 1)
+
+		if err := encoder.Add(frame, time.Duration(i)*encoding.FrameInterval); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filename := filepath.Join(t.TempDir(), "compact.abyss")
+	writeRecording(t, filename, &encoding.AbyssRecording{OverviewFrames: encoder.Frames()})
+
+	if _, err := upload(context.Background(), server.Client(), server.URL, filename); err != nil {
+		t.Fatalf("upload() error = %v", err)
+	}
+
+	got, err := encoding.Decode(bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// abyssal.space reads overview only as GIF
+	anim, err := gif.DecodeAll(bytes.NewReader(got.GetOverview()))
+	if err != nil || len(anim.Image) != 3 || got.GetOverviewFrames() != nil {
+		t.Errorf("posted overview is not GIF of 3 frames: %v", err)
+	}
+}
@@ -0,0 +1,313 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/combatlog"
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+// FrameInterval is how often recorder captures overview frame.
+const FrameInterval = encoding.FrameInterval
+
+// DefaultDPSInterval is default width of DPS timeline bucket.
+const DefaultDPSInterval = 10 * time.Second
+
+// Options controls how recording is analyzed.
+type Options struct {
+	DPSInterval time.Duration
+}
+
+// Report is summary of single recorded abyss run.
+type Report struct {
+	Filename                string     `json:"filename,omitempty"`
+	RecorderVersion         string     `json:"recorderVersion"`
+	TestServer              bool       `json:"testServer"`
+	Frames                  int        `json:"frames"`
+	DroppedFrames           int        `json:"droppedFrames"`
+	DurationSeconds         int64      `json:"durationSeconds"`
+	StartedAt               *time.Time `json:"startedAt,omitempty"`
+	StoppedAt               *time.Time `json:"stoppedAt,omitempty"`
+	WeatherStrength         int32      `json:"weatherStrength"`
+	LootRecordDiscriminator string     `json:"lootRecordDiscriminator"`
+	// IgnoredClipboard is count of clipboard changes recorder ignored, because they were not inventory copies.
+	IgnoredClipboard  int32             `json:"ignoredClipboard,omitempty"`
+	AbyssTypeOverride *AbyssType        `json:"abyssTypeOverride,omitempty"`
+	Fittings          []Fitting         `json:"fittings"`
+	Characters        []CharacterReport `json:"characters"`
+	Loot              []LootEntry       `json:"loot"`
+	// Value of run, set by Valuate when prices are available.
+	Value *Valuation `json:"value,omitempty"`
+}
+
+// AbyssType is manually chosen abyss ship type, tier and weather.
+type AbyssType struct {
+	ShipType string `json:"shipType"`
+	Tier     int32  `json:"tier"`
+	Weather  string `json:"weather"`
+}
+
+// Fitting used by character during run.
+type Fitting struct {
+	Character   string  `json:"character"`
+	FittingName string  `json:"fittingName"`
+	ShipName    string  `json:"shipName"`
+	ShipTypeID  int32   `json:"shipTypeID"`
+	Price       float64 `json:"price"`
+	Source      string  `json:"source"`
+}
+
+// CharacterReport holds combat statistics of single character.
+type CharacterReport struct {
+	Character      string      `json:"character"`
+	Language       string      `json:"language"`
+	DamageDealt    int         `json:"damageDealt"`
+	DamageReceived int         `json:"damageReceived"`
+	ShotsHit       int         `json:"shotsHit"`
+	ShotsMissed    int         `json:"shotsMissed"`
+	HitsTaken      int         `json:"hitsTaken"`
+	MissesTaken    int         `json:"missesTaken"`
+	UnparsedLines  int         `json:"unparsedLines"`
+	DPS            []DPSBucket `json:"dps"`
+}
+
+// DPSBucket is damage per second in time window starting at OffsetSeconds from first overview frame (or first combat event for recordings without frame timestamps).
+type DPSBucket struct {
+	OffsetSeconds int64   `json:"offsetSeconds"`
+	Dealt         float64 `json:"dealt"`
+	Received      float64 `json:"received"`
+}
+
+// LootEntry is loot snapshot placed on run timeline.
+type LootEntry struct {
+	Frame         int32  `json:"frame"`
+	OffsetSeconds int64  `json:"offsetSeconds"`
+	Items         int    `json:"items"`
+	Loot          string `json:"loot"`
+	// Character cargo was copied from, empty when recorder could not resolve it.
+	Character string `json:"character,omitempty"`
+}
+
+// Analyze builds run report from decoded recording.
+func Analyze(rec *encoding.AbyssRecording, opts Options) (*Report, error) {
+	if opts.DPSInterval <= 0 {
+		opts.DPSInterval = DefaultDPSInterval
+	}
+
+	frames, err := rec.FrameCount()
+	if errors.Is(err, encoding.ErrNoOverview) {
+		frames, err = 0, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding overview: %w", err)
+	}
+
+	r := &Report{
+		RecorderVersion:         rec.GetRecorderVersion(),
+		TestServer:              rec.GetTestServer(),
+		Frames:                  frames,
+		DurationSeconds:         int64(time.Duration(frames) * FrameInterval / time.Second),
+		StartedAt:               unixMilli(rec.GetStartedAt()),
+		StoppedAt:               unixMilli(rec.GetStoppedAt()),
+		WeatherStrength:         rec.GetWeatherStrength(),
+		LootRecordDiscriminator: rec.GetLootRecordDiscriminator(),
+		IgnoredClipboard:        rec.GetIgnoredClipboard(),
+		Fittings:                []Fitting{},
+		Characters:              []CharacterReport{},
+		Loot:                    []LootEntry{},
+	}
+
+	firstFrame, timed := rec.FrameTime(0)
+	if timed {
+		lastFrame, _ := rec.FrameTime(len(rec.GetFrameTimestamps()) - 1)
+		r.DurationSeconds = int64((lastFrame.Sub(firstFrame) + FrameInterval) / time.Second)
+		r.DroppedFrames = droppedFrames(rec.GetFrameTimestamps())
+	}
+
+	if rec.GetManualAbyssTypeOverride() {
+		r.AbyssTypeOverride = &AbyssType{
+			ShipType: rec.GetAbyssShipType().String(),
+			Tier:     rec.GetAbyssTier(),
+			Weather:  rec.GetAbyssWheather(),
+		}
+	}
+
+	for character, fit := range rec.GetFittings() {
+		r.Fittings = append(r.Fittings, Fitting{
+			Character:   character,
+			FittingName: fit.GetFittingName(),
+			ShipName:    fit.GetShipName(),
+			ShipTypeID:  fit.GetShipTypeID(),
+			Price:       fit.GetPrice(),
+			Source:      fit.GetSource(),
+		})
+	}
+
+	sort.Slice(r.Fittings, func(i, j int) bool { return r.Fittings[i].Character < r.Fittings[j].Character })
+
+	for _, lr := range rec.GetLoot() {
+		offset := time.Duration(lr.GetFrame()) * FrameInterval
+
+		if at, ok := rec.FrameTime(int(lr.GetFrame())); ok {
+			offset = at.Sub(firstFrame)
+		}
+
+		if timed && lr.GetTimestamp() != 0 {
+			offset = time.UnixMilli(lr.GetTimestamp()).Sub(firstFrame)
+		}
+
+		r.Loot = append(r.Loot, LootEntry{
+			Frame:         lr.GetFrame(),
+			OffsetSeconds: int64(offset / time.Second),
+			Items:         countLines(lr.GetLoot()),
+			Loot:          lr.GetLoot(),
+			Character:     lr.GetCharacter(),
+		})
+	}
+
+	parsed := parseCombatLog(rec)
+	start := timelineStart(rec, parsed)
+
+	for _, record := range rec.GetCombatLog() {
+		r.Characters = append(r.Characters, characterReport(record, parsed[record.GetCharacterName()], start, opts.DPSInterval))
+	}
+
+	sort.Slice(r.Characters, func(i, j int) bool { return r.Characters[i].Character < r.Characters[j].Character })
+
+	return r, nil
+}
+
+// parseCombatLog parses combat log of every character, keyed by character.
+func parseCombatLog(rec *encoding.AbyssRecording) map[string][]*combatlog.Event {
+	parsed := make(map[string][]*combatlog.Event, len(rec.GetCombatLog()))
+
+	for _, record := range rec.GetCombatLog() {
+		parsed[record.GetCharacterName()] = combatlog.ParseRecord(record)
+	}
+
+	return parsed
+}
+
+// timelineStart returns time run timeline starts at.
+// Combat log timestamps have second precision, so timeline starts at second of first frame capture,
+// recordings without frame timestamps start at first combat event.
+func timelineStart(rec *encoding.AbyssRecording, parsed map[string][]*combatlog.Event) time.Time {
+	if firstFrame, ok := rec.FrameTime(0); ok {
+		return firstFrame.Truncate(time.Second)
+	}
+
+	var start time.Time
+
+	for _, events := range parsed {
+		for _, e := range events {
+			if e.Type == combatlog.EventDamage || e.Type == combatlog.EventMiss {
+				if start.IsZero() || e.Timestamp.Before(start) {
+					start = e.Timestamp
+				}
+			}
+		}
+	}
+
+	return start
+}
+
+func characterReport(record *combatlog.CombatLogRecord, events []*combatlog.Event, start time.Time, interval time.Duration) CharacterReport {
+	cr := CharacterReport{
+		Character: record.GetCharacterName(),
+		Language:  record.GetLanguageCode().String(),
+		DPS:       []DPSBucket{},
+	}
+
+	buckets := make(map[int64]*DPSBucket)
+
+	for _, e := range events {
+		switch e.Type {
+		case combatlog.EventUnparsed:
+			cr.UnparsedLines++
+			continue
+		case combatlog.EventMiss:
+			if e.Direction == combatlog.DirectionOutgoing {
+				cr.ShotsMissed++
+			} else {
+				cr.MissesTaken++
+			}
+
+			continue
+		case combatlog.EventDamage:
+		default:
+			continue
+		}
+
+		since := e.Timestamp.Sub(start)
+		idx := int64(since / interval)
+
+		if since < 0 && since%interval != 0 { // events before first frame go to preceding bucket
+			idx--
+		}
+
+		b, ok := buckets[idx]
+		if !ok {
+			b = &DPSBucket{OffsetSeconds: int64(time.Duration(idx) * interval / time.Second)}
+			buckets[idx] = b
+		}
+
+		if e.Direction == combatlog.DirectionOutgoing {
+			cr.DamageDealt += e.Amount
+			cr.ShotsHit++
+			b.Dealt += float64(e.Amount) / interval.Seconds()
+		} else {
+			cr.DamageReceived += e.Amount
+			cr.HitsTaken++
+			b.Received += float64(e.Amount) / interval.Seconds()
+		}
+	}
+
+	for _, b := range buckets {
+		cr.DPS = append(cr.DPS, *b)
+	}
+
+	sort.Slice(cr.DPS, func(i, j int) bool { return cr.DPS[i].OffsetSeconds < cr.DPS[j].OffsetSeconds })
+
+	return cr
+}
+
+// droppedFrames counts frames missing on timeline, gap between frames longer than FrameInterval means frames were dropped.
+func droppedFrames(timestamps []int64) int {
+	dropped := 0
+
+	for i := 1; i < len(timestamps); i++ {
+		gap := time.Duration(timestamps[i]-timestamps[i-1]) * time.Millisecond
+		if missing := int((gap+FrameInterval/2)/FrameInterval) - 1; missing > 0 {
+			dropped += missing
+		}
+	}
+
+	return dropped
+}
+
+func unixMilli(ms int64) *time.Time {
+	if ms == 0 {
+		return nil
+	}
+
+	t := time.UnixMilli(ms).UTC()
+
+	return &t
+}
+
+func countLines(s string) int {
+	count := 0
+
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,163 @@
+package analysis
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/combatlog"
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+func testOverview(t *testing.T, frames int) []byte {
+	t.Helper()
+
+	anim := gif.GIF{}
+
+	for i := 0; i < frames; i++ {
+		anim.Image = append(anim.Image, image.NewPaletted(image.Rect(0, 0, 10, 10), []color.Color{color.White, color.Black}))
+		anim.Delay = append(anim.Delay, 10)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, &anim); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestAnalyze(t *testing.T) {
+	rec := &encoding.AbyssRecording{
+		Overview: testOverview(t, 120),
+		Loot: []*encoding.LootRecord{
+			{Frame: 0, Loot: "Calm Dark Filament\t1\nQuafe\t1\n"},
+			{Frame: 90, Loot: "Quafe\t1\nTriglavian Survey Database\t12\n\n"},
+		},
+		CombatLog: []*combatlog.CombatLogRecord{
+			{
+				CharacterName: "Runner1",
+				LanguageCode:  combatlog.LanguageCode_ENGLISH,
+				CombatLogLines: []string{
+					"[ 2020.10.22 21:00:57 ] (combat) <color=0xff00ffff><b>180</b> <color=0x77ffffff><font size=10>to</font> <b><color=0xffffffff>Gistii Rogue</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Penetrates",
+					"[ 2020.10.22 21:01:05 ] (combat) <color=0xff00ffff><b>20</b> <color=0x77ffffff><font size=10>to</font> <b><color=0xffffffff>Gistii Thug</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Hits",
+					"[ 2020.10.22 21:01:28 ] (combat) Gistii Ambusher misses you completely",
+					"[ 2020.10.22 21:01:33 ] (combat) <color=0xffcc0000><b>11</b> <color=0x77ffffff><font size=10>from</font> <b><color=0xffffffff>Gistii Ambusher</b><font size=10><color=0x77ffffff> - Nova Light Missile - Hits",
+					"[ 2020.10.22 21:02:19 ] (notify) Ship stopping",
+					"",
+				},
+			},
+		},
+		WeatherStrength:         50,
+		LootRecordDiscriminator: "Quafe",
+		ManualAbyssTypeOverride: true,
+		AbyssShipType:           encoding.AbyssRecording_CRUISER,
+		AbyssTier:               1,
+		AbyssWheather:           "Dark",
+		Fittings:                map[string]*encoding.Fit{"Runner1": {FittingName: "FFA", ShipName: "Nergal", ShipTypeID: 52250}},
+		RecorderVersion:         "test",
+	}
+
+	got, err := Analyze(rec, Options{DPSInterval: 20 * time.Second})
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	want := &Report{
+		RecorderVersion:         "test",
+		Frames:                  120,
+		DurationSeconds:         120,
+		WeatherStrength:         50,
+		LootRecordDiscriminator: "Quafe",
+		AbyssTypeOverride:       &AbyssType{ShipType: "CRUISER", Tier: 1, Weather: "Dark"},
+		Fittings:                []Fitting{{Character: "Runner1", FittingName: "FFA", ShipName: "Nergal", ShipTypeID: 52250}},
+		Characters: []CharacterReport{
+			{
+				Character:      "Runner1",
+				Language:       "ENGLISH",
+				DamageDealt:    200,
+				DamageReceived: 11,
+				ShotsHit:       2,
+				HitsTaken:      1,
+				MissesTaken:    1,
+				UnparsedLines:  1,
+				DPS: []DPSBucket{
+					{OffsetSeconds: 0, Dealt: 10},
+					{OffsetSeconds: 20, Received: 0.55},
+				},
+			},
+		},
+		Loot: []LootEntry{
+			{Frame: 0, OffsetSeconds: 0, Items: 2, Loot: "Calm Dark Filament\t1\nQuafe\t1\n"},
+			{Frame: 90, OffsetSeconds: 90, Items: 2, Loot: "Quafe\t1\nTriglavian Survey Database\t12\n\n"},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Analyze() = %+v, want %+v", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteText(&buf, got); err != nil {
+		t.Errorf("WriteText() error = %v", err)
+	}
+}
+
+func TestAnalyze_FrameTimestamps(t *testing.T) {
+	first := time.Date(2020, 10, 22, 21, 0, 55, 300*int(time.Millisecond), time.UTC)
+	var timestamps []int64
+
+	// frame at 3s was dropped by capture loop
+	for _, offset := range []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		timestamps = append(timestamps, first.Add(offset).UnixMilli())
+	}
+
+	rec := &encoding.AbyssRecording{
+		Overview:        testOverview(t, 5),
+		FrameTimestamps: timestamps,
+		StartedAt:       first.Add(-2 * time.Second).UnixMilli(),
+		Loot: []*encoding.LootRecord{
+			{Frame: 2, Loot: "Quafe\t1\n", Timestamp: first.Add(3500 * time.Millisecond).UnixMilli()},
+			{Frame: 3, Loot: "Quafe\t1\n"},
+		},
+		CombatLog: []*combatlog.CombatLogRecord{
+			{
+				CharacterName: "Runner1",
+				LanguageCode:  combatlog.LanguageCode_ENGLISH,
+				CombatLogLines: []string{
+					"[ 2020.10.22 21:00:50 ] (combat) <color=0xff00ffff><b>100</b> <color=0x77ffffff><font size=10>to</font> <b><color=0xffffffff>Gistii Rogue</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Hits",
+					"[ 2020.10.22 21:00:57 ] (combat) <color=0xff00ffff><b>180</b> <color=0x77ffffff><font size=10>to</font> <b><color=0xffffffff>Gistii Rogue</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Penetrates",
+				},
+			},
+		},
+	}
+
+	got, err := Analyze(rec, Options{DPSInterval: 10 * time.Second})
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if got.DurationSeconds != 6 || got.DroppedFrames != 1 {
+		t.Errorf("Analyze() duration = %d, dropped frames = %d, want 6 and 1", got.DurationSeconds, got.DroppedFrames)
+	}
+
+	if got.StartedAt == nil || !got.StartedAt.Equal(first.Add(-2*time.Second).Truncate(time.Millisecond)) || got.StoppedAt != nil {
+		t.Errorf("Analyze() started = %v, stopped = %v", got.StartedAt, got.StoppedAt)
+	}
+
+	wantLoot := []int64{3, 4}
+	for i, l := range got.Loot {
+		if l.OffsetSeconds != wantLoot[i] {
+			t.Errorf("Analyze() loot %d offset = %d, want %d", i, l.OffsetSeconds, wantLoot[i])
+		}
+	}
+
+	wantDPS := []DPSBucket{{OffsetSeconds: -10, Dealt: 10}, {OffsetSeconds: 0, Dealt: 18}}
+	if !reflect.DeepEqual(got.Characters[0].DPS, wantDPS) {
+		t.Errorf("Analyze() DPS = %+v, want %+v", got.Characters[0].DPS, wantDPS)
+	}
+}
@@ -0,0 +1,110 @@
+package analysis
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/prices"
+)
+
+// WriteText writes human readable report.
+func WriteText(w io.Writer, r *Report) error {
+	p := &printer{w: w}
+
+	if r.Filename != "" {
+		p.printf("Recording: %s\n", r.Filename)
+	}
+
+	server := "Live server (tranquility)"
+	if r.TestServer {
+		server = "Test server (singularity)"
+	}
+
+	p.printf("Recorder version: %s, %s\n", r.RecorderVersion, server)
+	if r.StartedAt != nil {
+		p.printf("Started: %s UTC\n", r.StartedAt.Format(time.DateTime))
+	}
+
+	p.printf("Duration: %s (%d frames, %d dropped)\n", time.Duration(r.DurationSeconds)*time.Second, r.Frames, r.DroppedFrames)
+	p.printf("Weather strength: %d%%, loot record discriminator: %q\n", r.WeatherStrength, r.LootRecordDiscriminator)
+
+	if r.AbyssTypeOverride != nil {
+		p.printf("Abyss type override: %s T%d %s\n", r.AbyssTypeOverride.ShipType, r.AbyssTypeOverride.Tier, r.AbyssTypeOverride.Weather)
+	} else {
+		p.printf("Abyss type override: none\n")
+	}
+
+	p.printf("\nFittings:\n")
+
+	for _, f := range r.Fittings {
+		p.printf("  %s: %s (%s, typeID %d), price: %.2f ISK\n", f.Character, f.FittingName, f.ShipName, f.ShipTypeID, f.Price)
+	}
+
+	p.printf("\nCombat:\n")
+
+	for i := range r.Characters {
+		c := &r.Characters[i]
+		p.printf("  %s [%s]: dealt %d (%d hits, %d misses), received %d (%d hits, %d misses), unparsed lines: %d\n",
+			c.Character, c.Language, c.DamageDealt, c.ShotsHit, c.ShotsMissed, c.DamageReceived, c.HitsTaken, c.MissesTaken, c.UnparsedLines)
+
+		for _, b := range c.DPS {
+			p.printf("    %8s  dealt %8.1f dps  received %8.1f dps\n", time.Duration(b.OffsetSeconds)*time.Second, b.Dealt, b.Received)
+		}
+	}
+
+	p.printf("\nLoot timeline:\n")
+
+	if r.IgnoredClipboard > 0 {
+		p.printf("  %d clipboard change(s) ignored, not inventory copies\n", r.IgnoredClipboard)
+	}
+
+	for _, l := range r.Loot {
+		p.printf("  %8s  frame %d, %d item stacks", time.Duration(l.OffsetSeconds)*time.Second, l.Frame, l.Items)
+
+		if l.Character != "" {
+			p.printf(", cargo of %s", l.Character)
+		}
+
+		p.printf("\n")
+	}
+
+	if v := r.Value; v != nil {
+		p.printf("\nValue:\n")
+		p.printf("  Loot gained: %s\n", prices.FormatISK(v.LootGained))
+		p.printf("  Ammo consumed: %s, filaments consumed: %s\n", prices.FormatISK(v.AmmoConsumed), prices.FormatISK(v.FilamentsConsumed))
+
+		characters := make([]string, 0, len(v.Fittings))
+		for character := range v.Fittings {
+			characters = append(characters, character)
+		}
+
+		sort.Strings(characters)
+
+		for _, character := range characters {
+			p.printf("  Fitting of %s: %s\n", character, prices.FormatISK(v.Fittings[character]))
+		}
+
+		if len(v.Missing) > 0 {
+			p.printf("  No price of: %s\n", strings.Join(v.Missing, ", "))
+		}
+	}
+
+	return p.err
+}
+
+// printer remembers first write error, so report can be written without checking every line.
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+func (p *printer) printf(format string, args ...interface{}) {
+	if p.err != nil {
+		return
+	}
+
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
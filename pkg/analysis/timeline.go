@@ -0,0 +1,185 @@
+package analysis
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/combatlog"
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+// Directions of timeline entries.
+const (
+	DirectionDealt    = "dealt"
+	DirectionReceived = "received"
+)
+
+// timelineHeader is header of CSV timeline, column order is part of format.
+var timelineHeader = []string{"offset_seconds", "time", "character", "direction", "weapon", "source", "target", "damage", "hits", "misses"}
+
+// Timeline is per second damage timeline of run.
+type Timeline struct {
+	// Start is time offsets are relative to, nil when combat log has no combat events.
+	Start   *time.Time      `json:"start,omitempty"`
+	Entries []TimelineEntry `json:"entries"`
+}
+
+// TimelineEntry is damage character dealt or received during single second of run, from single weapon and source to single target.
+// Source of dealt damage and target of received damage is character itself, weapon of received damage is often unknown (NPC shots).
+type TimelineEntry struct {
+	OffsetSeconds int64  `json:"offsetSeconds"`
+	Character     string `json:"character"`
+	Direction     string `json:"direction"`
+	Weapon        string `json:"weapon"`
+	Source        string `json:"source"`
+	Target        string `json:"target"`
+	Damage        int    `json:"damage"`
+	Hits          int    `json:"hits"`
+	Misses        int    `json:"misses"`
+}
+
+type timelineKey struct {
+	offset    int64
+	character string
+	direction string
+	weapon    string
+	source    string
+	target    string
+}
+
+// DamageTimeline builds per second damage timeline of every character out of recorded combat log.
+// Offsets are relative to same start as DPS buckets of Report.
+func DamageTimeline(rec *encoding.AbyssRecording) *Timeline {
+	parsed := parseCombatLog(rec)
+	start := timelineStart(rec, parsed)
+	t := &Timeline{Entries: []TimelineEntry{}}
+
+	if start.IsZero() {
+		return t
+	}
+
+	utc := start.UTC()
+	t.Start = &utc
+
+	entries := make(map[timelineKey]*TimelineEntry)
+
+	for character, events := range parsed {
+		for _, e := range events {
+			if e.Type != combatlog.EventDamage && e.Type != combatlog.EventMiss {
+				continue
+			}
+
+			since := e.Timestamp.Sub(start)
+			offset := int64(since / time.Second)
+
+			if since < 0 && since%time.Second != 0 {
+				offset--
+			}
+
+			key := timelineKey{offset: offset, character: character, weapon: e.Weapon, source: e.Source, target: e.Target}
+
+			switch e.Direction {
+			case combatlog.DirectionOutgoing:
+				key.direction = DirectionDealt
+			case combatlog.DirectionIncoming:
+				key.direction = DirectionReceived
+			default:
+				continue
+			}
+
+			entry, ok := entries[key]
+			if !ok {
+				entry = &TimelineEntry{
+					OffsetSeconds: offset,
+					Character:     character,
+					Direction:     key.direction,
+					Weapon:        e.Weapon,
+					Source:        e.Source,
+					Target:        e.Target,
+				}
+				entries[key] = entry
+			}
+
+			if e.Type == combatlog.EventMiss {
+				entry.Misses++
+				continue
+			}
+
+			entry.Damage += e.Amount
+			entry.Hits++
+		}
+	}
+
+	for _, entry := range entries {
+		t.Entries = append(t.Entries, *entry)
+	}
+
+	sort.Slice(t.Entries, func(i, j int) bool {
+		a, b := t.Entries[i], t.Entries[j]
+
+		switch {
+		case a.OffsetSeconds != b.OffsetSeconds:
+			return a.OffsetSeconds < b.OffsetSeconds
+		case a.Character != b.Character:
+			return a.Character < b.Character
+		case a.Direction != b.Direction:
+			return a.Direction < b.Direction
+		case a.Weapon != b.Weapon:
+			return a.Weapon < b.Weapon
+		case a.Source != b.Source:
+			return a.Source < b.Source
+		default:
+			return a.Target < b.Target
+		}
+	})
+
+	return t
+}
+
+// WriteCSV writes timeline as CSV, one row per entry, time column holds UTC time of second in RFC 3339 format.
+func (t *Timeline) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(timelineHeader); err != nil {
+		return err
+	}
+
+	for _, e := range t.Entries {
+		var at string
+		if t.Start != nil {
+			at = t.Start.Add(time.Duration(e.OffsetSeconds) * time.Second).Format(time.RFC3339)
+		}
+
+		err := cw.Write([]string{
+			strconv.FormatInt(e.OffsetSeconds, 10),
+			at,
+			e.Character,
+			e.Direction,
+			e.Weapon,
+			e.Source,
+			e.Target,
+			strconv.Itoa(e.Damage),
+			strconv.Itoa(e.Hits),
+			strconv.Itoa(e.Misses),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// WriteJSON writes timeline as indented JSON document.
+func (t *Timeline) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(t)
+}
@@ -0,0 +1,62 @@
+package analysis
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/combatlog"
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+func TestDamageTimeline(t *testing.T) {
+	rec := &encoding.AbyssRecording{
+		CombatLog: []*combatlog.CombatLogRecord{
+			{
+				CharacterName: "Runner1",
+				LanguageCode:  combatlog.LanguageCode_ENGLISH,
+				CombatLogLines: []string{
+					"[ 2020.10.22 21:00:57 ] (combat) <color=0xff00ffff><b>180</b> <color=0x77ffffff><font size=10>to</font> <b><color=0xffffffff>Gistii Rogue</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Penetrates",
+					"[ 2020.10.22 21:00:57 ] (combat) <color=0xff00ffff><b>120</b> <color=0x77ffffff><font size=10>to</font> <b><color=0xffffffff>Gistii Rogue</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Hits",
+					"[ 2020.10.22 21:01:05 ] (combat) <color=0xff00ffff><b>20</b> <color=0x77ffffff><font size=10>to</font> <b><color=0xffffffff>Gistii Thug</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Hits",
+					"[ 2020.10.22 21:01:28 ] (combat) Gistii Ambusher misses you completely",
+					"[ 2020.10.22 21:01:33 ] (combat) <color=0xffcc0000><b>11</b> <color=0x77ffffff><font size=10>from</font> <b><color=0xffffffff>Gistii Ambusher</b><font size=10><color=0x77ffffff> - Nova Light Missile - Hits",
+					"[ 2020.10.22 21:02:19 ] (notify) Ship stopping",
+				},
+			},
+		},
+	}
+
+	got := DamageTimeline(rec)
+
+	start := time.Date(2020, 10, 22, 21, 0, 57, 0, time.UTC)
+	want := &Timeline{
+		Start: &start,
+		Entries: []TimelineEntry{
+			{OffsetSeconds: 0, Character: "Runner1", Direction: DirectionDealt, Weapon: "Veles Light Entropic Disintegrator", Source: "Runner1", Target: "Gistii Rogue", Damage: 300, Hits: 2},
+			{OffsetSeconds: 8, Character: "Runner1", Direction: DirectionDealt, Weapon: "Veles Light Entropic Disintegrator", Source: "Runner1", Target: "Gistii Thug", Damage: 20, Hits: 1},
+			{OffsetSeconds: 31, Character: "Runner1", Direction: DirectionReceived, Source: "Gistii Ambusher", Target: "Runner1", Misses: 1},
+			{OffsetSeconds: 36, Character: "Runner1", Direction: DirectionReceived, Weapon: "Nova Light Missile", Source: "Gistii Ambusher", Target: "Runner1", Damage: 11, Hits: 1},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("DamageTimeline() = %+v, want %+v", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := got.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	wantCSV := "offset_seconds,time,character,direction,weapon,source,target,damage,hits,misses\n" +
+		"0,2020-10-22T21:00:57Z,Runner1,dealt,Veles Light Entropic Disintegrator,Runner1,Gistii Rogue,300,2,0\n" +
+		"8,2020-10-22T21:01:05Z,Runner1,dealt,Veles Light Entropic Disintegrator,Runner1,Gistii Thug,20,1,0\n" +
+		"31,2020-10-22T21:01:28Z,Runner1,received,,Gistii Ambusher,Runner1,0,0,1\n" +
+		"36,2020-10-22T21:01:33Z,Runner1,received,Nova Light Missile,Gistii Ambusher,Runner1,11,1,0\n"
+
+	if buf.String() != wantCSV {
+		t.Errorf("WriteCSV() = %q, want %q", buf.String(), wantCSV)
+	}
+}
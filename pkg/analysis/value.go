@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+	"github.com/shivas/abyss-blackbox/pkg/inventory"
+	"github.com/shivas/abyss-blackbox/pkg/prices"
+)
+
+// Valuation is ISK value of run.
+type Valuation struct {
+	// LootGained is value of items gained over run, every character's cargo is compared with its own earlier snapshot.
+	LootGained        float64 `json:"lootGained"`
+	AmmoConsumed      float64 `json:"ammoConsumed"`
+	FilamentsConsumed float64 `json:"filamentsConsumed"`
+	// Fittings is cost of fitting of every character, price stored with fitting is used when set.
+	Fittings map[string]float64 `json:"fittings"`
+	// Missing are items without price, not included in values above.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// Valuate prices loot gained, ammo and filaments consumed and fittings of recorded run.
+// When provider fails, valuation by prices it still returned is returned together with error.
+func Valuate(ctx context.Context, rec *encoding.AbyssRecording, p prices.PriceProvider) (*Valuation, error) {
+	gained, ammo, filaments := map[string]int64{}, map[string]int64{}, map[string]int64{}
+
+	for _, d := range inventory.RecordingDeltas(rec.GetLoot()) {
+		addChanges(gained, d.Gained)
+		addChanges(ammo, d.ConsumedAmmo)
+		addChanges(filaments, d.ConsumedFilaments)
+	}
+
+	fittings := make(map[string]map[string]int64)
+	v := &Valuation{Fittings: make(map[string]float64)}
+
+	for character, fit := range rec.GetFittings() {
+		if fit.GetPrice() > 0 {
+			v.Fittings[character] = fit.GetPrice()
+			continue
+		}
+
+		if fit.GetEFT() != "" {
+			fittings[character] = prices.FittingItems(fit.GetEFT())
+		}
+	}
+
+	names := make(map[string]bool)
+	collect := func(items map[string]int64) {
+		for name := range items {
+			names[name] = true
+		}
+	}
+
+	collect(gained)
+	collect(ammo)
+	collect(filaments)
+
+	for _, items := range fittings {
+		collect(items)
+	}
+
+	list := make([]string, 0, len(names))
+	for name := range names {
+		list = append(list, name)
+	}
+
+	sort.Strings(list)
+
+	known, err := p.Prices(ctx, list)
+
+	missing := make(map[string]bool)
+	value := func(items map[string]int64) float64 {
+		isk, unknown := known.Value(items)
+		for _, name := range unknown {
+			missing[name] = true
+		}
+
+		return isk
+	}
+
+	v.LootGained = value(gained)
+	v.AmmoConsumed = value(ammo)
+	v.FilamentsConsumed = value(filaments)
+
+	for character, items := range fittings {
+		v.Fittings[character] = value(items)
+	}
+
+	for name := range missing {
+		v.Missing = append(v.Missing, name)
+	}
+
+	sort.Strings(v.Missing)
+
+	return v, err
+}
+
+func addChanges(quantities map[string]int64, changes []inventory.Change) {
+	for _, c := range changes {
+		quantities[c.Name] += c.Quantity
+	}
+}
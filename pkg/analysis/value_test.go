@@ -0,0 +1,49 @@
+package analysis
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+	"github.com/shivas/abyss-blackbox/pkg/prices"
+)
+
+func TestValuate(t *testing.T) {
+	rec := &encoding.AbyssRecording{
+		Loot: []*encoding.LootRecord{
+			{Frame: 0, Loot: "Calm Dark Filament\t1\nQuafe\t1\nScourge Fury Light Missile\t1000\tAdvanced Light Missile\tCharge\n"},
+			{Frame: 90, Loot: "Quafe\t1\nScourge Fury Light Missile\t400\tAdvanced Light Missile\tCharge\nTriglavian Survey Database\t12\nDecayed Warp Disruptor Mutaplasmid\t1\n"},
+		},
+		Fittings: map[string]*encoding.Fit{
+			"Runner1": {EFT: "[Nergal, FFA]\nSmall Capacitor Booster II\n\nHornet EC-300 x2\n"},
+			"Runner2": {EFT: "[Nergal, FFA]\n", Price: 9e7},
+		},
+	}
+
+	p := prices.NewFileProvider([]prices.Entry{
+		{Name: "Calm Dark Filament", Price: 3.6e6},
+		{Name: "Scourge Fury Light Missile", Price: 300},
+		{Name: "Triglavian Survey Database", Price: 1e5},
+		{Name: "Nergal", Price: 7e7},
+		{Name: "Small Capacitor Booster II", Price: 1e6},
+		{Name: "Hornet EC-300", Price: 5e3},
+	})
+
+	got, err := Valuate(context.Background(), rec, p)
+	if err != nil {
+		t.Fatalf("Valuate() error = %v", err)
+	}
+
+	want := &Valuation{
+		LootGained:        1.2e6,
+		AmmoConsumed:      1.8e5,
+		FilamentsConsumed: 3.6e6,
+		Fittings:          map[string]float64{"Runner1": 7.101e7, "Runner2": 9e7},
+		Missing:           []string{"Decayed Warp Disruptor Mutaplasmid"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Valuate() = %+v, want %+v", got, want)
+	}
+}
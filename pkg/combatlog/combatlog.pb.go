@@ -20,6 +20,10 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// LanguageCode of gamelog, covers every language game client writes logs in.
+// Codes from 100 up are not listed here, they are assigned to languages registered at runtime
+// (combatlog.RegisterLanguage), so they never collide with languages added here later.
+// Recordings store such languages as UNKNOWN.
 type LanguageCode int32
 
 const (
@@ -0,0 +1,246 @@
+package combatlog
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// LineKind is kind of gamelog line recognized by language detection.
+type LineKind int
+
+const (
+	KindUnknown LineKind = iota
+	KindDamage
+	KindMiss
+	KindWarpScramble
+	KindNotify
+)
+
+func (k LineKind) String() string {
+	switch k {
+	case KindDamage:
+		return "damage"
+	case KindMiss:
+		return "miss"
+	case KindWarpScramble:
+		return "warp scramble"
+	case KindNotify:
+		return "notify"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// MinConfidence is confidence detection needs to override language detected from gamelog header.
+	MinConfidence = 0.75
+	// minDetectedLines is how many lines must be recognized before detection is trusted.
+	minDetectedLines = 5
+	// mixedShare is share of lines second language must be recognized in for log to be reported as mixed.
+	mixedShare = 0.1
+)
+
+// Detection is result of detecting language of gamelog body.
+type Detection struct {
+	// LanguageCode is most likely language, LanguageCode_UNKNOWN when no line was recognized.
+	LanguageCode LanguageCode
+	// Confidence is share of recognized lines written in LanguageCode, between 0 and 1.
+	Confidence float64
+	// Mixed is set when significant part of lines is written in other language.
+	Mixed bool
+	// Recognized is count of lines recognized by at least one language.
+	Recognized int
+	// Kinds counts recognized lines by kind.
+	Kinds map[LineKind]int
+}
+
+// Confident tells whether detection is reliable enough to be used instead of language from header.
+func (d Detection) Confident() bool {
+	return d.LanguageCode != LanguageCode_UNKNOWN && !d.Mixed && d.Recognized >= minDetectedLines && d.Confidence >= MinConfidence
+}
+
+// Refine returns detected language when detection is confident, hint otherwise.
+func (d Detection) Refine(hint LanguageCode) LanguageCode {
+	if d.Confident() {
+		return d.LanguageCode
+	}
+
+	return hint
+}
+
+// DetectLanguage detects language of gamelog lines from combat and notify phrases and script they are written in.
+// Lines matching several languages (shared words) split their vote.
+func DetectLanguage(lines []string) Detection {
+	d := Detection{LanguageCode: LanguageCode_UNKNOWN, Kinds: make(map[LineKind]int)}
+	scores := make(map[LanguageCode]float64)
+	unique := make(map[LanguageCode]int)
+
+	for _, line := range lines {
+		kind, languages := ClassifyLine(line)
+		if len(languages) == 0 {
+			continue
+		}
+
+		d.Recognized++
+		d.Kinds[kind]++
+
+		for _, languageCode := range languages {
+			scores[languageCode] += 1 / float64(len(languages))
+		}
+
+		if len(languages) == 1 {
+			unique[languages[0]]++
+		}
+	}
+
+	if d.Recognized == 0 {
+		return d
+	}
+
+	var best float64
+
+	for languageCode, score := range scores {
+		if score > best || score == best && languageCode < d.LanguageCode {
+			d.LanguageCode, best = languageCode, score
+		}
+	}
+
+	d.Confidence = best / float64(d.Recognized)
+
+	for languageCode, count := range unique {
+		if languageCode != d.LanguageCode && float64(count) >= mixedShare*float64(d.Recognized) {
+			d.Mixed = true
+		}
+	}
+
+	return d
+}
+
+// ClassifyLine returns kind of gamelog line and languages its phrasing belongs to, none when line is not recognized.
+func ClassifyLine(line string) (LineKind, []LanguageCode) {
+	m := lineRe.FindStringSubmatch(strings.TrimRight(line, "\r"))
+	if m == nil {
+		return KindUnknown, nil
+	}
+
+	category, body := Category(m[2]), m[3]
+	text := stripMarkup(body)
+
+	kind := KindUnknown
+	if category == CategoryNotify || category == CategoryHint {
+		kind = KindNotify
+	}
+
+	if category == CategoryCombat && damageRe.MatchString(body) {
+		kind = KindDamage
+	}
+
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+
+	if languages := scriptLanguages(text); len(languages) > 0 {
+		return kind, languages
+	}
+
+	var languages []LanguageCode
+
+	for _, languageCode := range languageCodes() {
+		g := grammars[languageCode]
+		if len(g.Scripts) > 0 {
+			continue
+		}
+
+		if k := g.classify(category, body, text); k != KindUnknown {
+			kind = k
+			languages = append(languages, languageCode)
+		}
+	}
+
+	return kind, languages
+}
+
+// classify tells kind of line when it is phrased in grammar language.
+func (g Grammar) classify(category Category, body, text string) LineKind {
+	switch category {
+	case CategoryCombat:
+		if m := damageRe.FindStringSubmatch(body); m != nil {
+			word := strings.TrimSpace(m[3])
+			if word == "" || g.direction(word) == DirectionNone {
+				return KindUnknown
+			}
+
+			parts := strings.Split(markupRe.ReplaceAllString(m[5], ""), " - ")
+			if _, ok := g.Qualities[strings.TrimSpace(parts[len(parts)-1])]; !ok {
+				return KindUnknown
+			}
+
+			return KindDamage
+		}
+
+		if matches(g.IncomingMissRe, text) || matches(g.OutgoingMissRe, text) {
+			return KindMiss
+		}
+
+		if matches(g.WarpScrambleRe, text) {
+			return KindWarpScramble
+		}
+	case CategoryNotify, CategoryHint:
+		for _, phrase := range g.Notify {
+			if strings.Contains(text, phrase) {
+				return KindNotify
+			}
+		}
+	}
+
+	return KindUnknown
+}
+
+// scriptLanguages returns languages written in script letters of text belong to, caller holds languagesMu.
+func scriptLanguages(text string) []LanguageCode {
+	found := make(map[LanguageCode]bool)
+
+	for _, r := range text {
+		if r < unicode.MaxLatin1 {
+			continue
+		}
+
+		for languageCode, g := range grammars {
+			if unicode.IsOneOf(g.Scripts, r) {
+				found[languageCode] = true
+			}
+		}
+	}
+
+	// japanese is written with chinese characters as well
+	if found[LanguageCode_JAPANESE] {
+		delete(found, LanguageCode_CHINESE)
+	}
+
+	var languages []LanguageCode
+
+	for _, languageCode := range languageCodes() {
+		if found[languageCode] {
+			languages = append(languages, languageCode)
+		}
+	}
+
+	return languages
+}
+
+// languageCodes returns languages with grammar, in stable order, caller holds languagesMu.
+func languageCodes() []LanguageCode {
+	codes := make([]LanguageCode, 0, len(grammars))
+	for languageCode := range grammars {
+		codes = append(codes, languageCode)
+	}
+
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	return codes
+}
+
+func matches(re *regexp.Regexp, s string) bool {
+	return re != nil && re.MatchString(s)
+}
@@ -0,0 +1,135 @@
+package combatlog
+
+import (
+	"bufio"
+	"os"
+	"testing"
+)
+
+const (
+	frenchDamage  = "[ 2020.10.22 21:00:57 ] (combat) <color=0xff00ffff><b>180</b> <color=0x77ffffff><font size=10>à</font> <b><color=0xffffffff>Gistii Rogue</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Pénètre"
+	germanDamage  = "[ 2020.10.22 21:01:33 ] (combat) <color=0xffcc0000><b>11</b> <color=0x77ffffff><font size=10>von</font> <b><color=0xffffffff>Gistii Ambusher</b><font size=10><color=0x77ffffff> - Nova Light Missile - Trifft"
+	germanMiss    = "[ 2020.10.22 21:01:28 ] (combat) Gistii Ambusher verfehlt Sie vollständig"
+	englishMiss   = "[ 2020.10.22 21:01:28 ] (combat) Gistii Ambusher misses you completely"
+	koreanNotify  = "[ 2020.10.22 21:01:28 ] (notify) 채널에 참여하는 중"
+	russianCombat = "[ 2020.10.22 21:01:28 ] (combat) Гистии промахивается по вам"
+)
+
+func repeatLines(n int, lines ...string) []string {
+	var result []string
+
+	for i := 0; i < n; i++ {
+		result = append(result, lines...)
+	}
+
+	return result
+}
+
+func TestDetectLanguage(t *testing.T) {
+	english := readTestdataBody(t, "testdata/20201022_205944.txt")
+
+	tests := []struct {
+		name      string
+		lines     []string
+		want      LanguageCode
+		mixed     bool
+		confident bool
+	}{
+		{name: "english testdata", lines: english, want: LanguageCode_ENGLISH, confident: true},
+		{name: "spanish testdata", lines: readTestdataBody(t, "testdata/20201022_205944_spanish.txt"), want: LanguageCode_SPANISH},
+		{name: "french damage", lines: repeatLines(5, frenchDamage), want: LanguageCode_FRENCH, confident: true},
+		{name: "german", lines: repeatLines(3, germanDamage, germanMiss), want: LanguageCode_GERMAN, confident: true},
+		// languages without combat grammar are known only from gamelog header
+		{name: "korean", lines: repeatLines(5, koreanNotify), want: LanguageCode_UNKNOWN},
+		{name: "russian", lines: repeatLines(5, russianCombat), want: LanguageCode_UNKNOWN},
+		{name: "mixed", lines: repeatLines(4, englishMiss, englishMiss, germanMiss), want: LanguageCode_ENGLISH, mixed: true},
+		{name: "unknown", lines: []string{"[ 2020.10.22 21:01:28 ] (bounty) 3 750,00 ISK added to next bounty payout", "garbage"}, want: LanguageCode_UNKNOWN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectLanguage(tt.lines)
+
+			if got.LanguageCode != tt.want || got.Mixed != tt.mixed || got.Confident() != tt.confident {
+				t.Errorf("DetectLanguage() = %+v, want %s, mixed %t, confident %t", got, tt.want, tt.mixed, tt.confident)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name         string
+		line         string
+		languageCode LanguageCode
+		want         string
+	}{
+		{
+			name:         "french damage",
+			line:         frenchDamage,
+			languageCode: LanguageCode_FRENCH,
+			want:         "[ 2020.10.22 21:00:57 ] (combat) <color=0xff00ffff><b>180</b> <color=0x77ffffff><font size=10>to</font> <b><color=0xffffffff>Gistii Rogue</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Penetrates",
+		},
+		{
+			name:         "german incoming damage",
+			line:         germanDamage,
+			languageCode: LanguageCode_GERMAN,
+			want:         "[ 2020.10.22 21:01:33 ] (combat) <color=0xffcc0000><b>11</b> <color=0x77ffffff><font size=10>from</font> <b><color=0xffffffff>Gistii Ambusher</b><font size=10><color=0x77ffffff> - Nova Light Missile - Hits",
+		},
+		{
+			name:         "german miss",
+			line:         germanMiss,
+			languageCode: LanguageCode_GERMAN,
+			want:         englishMiss,
+		},
+		{
+			name:         "english unchanged",
+			line:         englishMiss,
+			languageCode: LanguageCode_ENGLISH,
+			want:         englishMiss,
+		},
+		{
+			name:         "direction by color without grammar",
+			line:         frenchDamage,
+			languageCode: LanguageCode_KOREAN,
+			want:         "[ 2020.10.22 21:00:57 ] (combat) <color=0xff00ffff><b>180</b> <color=0x77ffffff><font size=10>to</font> <b><color=0xffffffff>Gistii Rogue</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Pénètre",
+		},
+		{
+			name:         "not understood unchanged",
+			line:         koreanNotify,
+			languageCode: LanguageCode_KOREAN,
+			want:         koreanNotify,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.line, tt.languageCode); got != tt.want {
+				t.Errorf("Normalize() = %q, want %q", got, tt.want)
+			}
+
+			if e := NewParser(LanguageCode_ENGLISH).ParseLine(Normalize(tt.line, tt.languageCode)); tt.line != tt.want && e.Type == EventUnparsed {
+				t.Errorf("normalized line not understood by english parser: %q", tt.want)
+			}
+		})
+	}
+}
+
+func readTestdataBody(t *testing.T, filename string) []string {
+	t.Helper()
+
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var lines []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines
+}
@@ -0,0 +1,52 @@
+package combatlog
+
+import (
+	"regexp"
+	"unicode"
+)
+
+// grammars holds combat line grammar per language, used by Parser and language detection.
+// It is filled from languages.json and RegisterLanguage, guarded by languagesMu.
+// Localized phrases are not complete, phrases missing only lower confidence of language detection.
+var grammars map[LanguageCode]Grammar
+
+// Grammar describes localized words used in combat lines.
+// Damage lines are matched structurally, so language without grammar still yields damage events,
+// direction is then derived from amount color, hit quality is left unknown and misses are not recognized.
+type Grammar struct {
+	To             string
+	From           string
+	Qualities      map[string]HitQuality
+	IncomingMissRe *regexp.Regexp
+	OutgoingMissRe *regexp.Regexp
+	// WarpScrambleRe matches warp scramble and disruption lines, capturing source and target.
+	WarpScrambleRe *regexp.Regexp
+	// Notify are fragments of common notify and hint lines.
+	Notify []string
+	// Scripts are set for languages written in own script, any letter of them identifies language.
+	Scripts []*unicode.RangeTable
+}
+
+func (g Grammar) direction(word string) Direction {
+	switch word {
+	case "":
+		return DirectionNone
+	case g.To:
+		return DirectionOutgoing
+	case g.From:
+		return DirectionIncoming
+	default:
+		return DirectionNone
+	}
+}
+
+// quality returns localized name of hit quality.
+func (g Grammar) quality(q HitQuality) (string, bool) {
+	for name, quality := range g.Qualities {
+		if quality == q {
+			return name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,154 @@
+package combatlog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	headerSeparator = "------------------------------------------------------------"
+	utf8BOM         = "\ufeff"
+	maxHeaderLines  = 16
+)
+
+var (
+	// ErrNoHeader returned when file doesn't start with gamelog banner.
+	ErrNoHeader = errors.New("gamelog header not found")
+	// ErrMalformedHeader returned when gamelog banner is incomplete or misses listener or session start.
+	ErrMalformedHeader = errors.New("malformed gamelog header")
+)
+
+// Header is banner every gamelog file starts with.
+type Header struct {
+	Listener       string
+	SessionStarted time.Time
+	LanguageCode   LanguageCode
+	// Length is size of banner in bytes, including byte order mark and closing separator, first log line starts there.
+	Length int64
+}
+
+// ParseHeader parses gamelog banner, detecting language of log from it. Reader is consumed past the banner.
+func ParseHeader(r io.Reader) (*Header, error) {
+	br := bufio.NewReader(r)
+	h := &Header{}
+
+	var (
+		session      string
+		haveListener bool
+		lines        int
+	)
+
+	for {
+		line, err := br.ReadString('\n')
+		h.Length += int64(len(line))
+
+		if err != nil && (err != io.EOF || line == "") {
+			if lines == 0 && err == io.EOF {
+				return nil, ErrNoHeader
+			}
+
+			if err == io.EOF {
+				return nil, fmt.Errorf("%w: closing separator missing", ErrMalformedHeader)
+			}
+
+			return nil, err
+		}
+
+		if lines == 0 {
+			line = strings.TrimPrefix(line, utf8BOM)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		lines++
+
+		if lines == 1 {
+			if line != headerSeparator {
+				return nil, ErrNoHeader
+			}
+
+			continue
+		}
+
+		if line == headerSeparator {
+			break
+		}
+
+		if lines > maxHeaderLines {
+			return nil, fmt.Errorf("%w: closing separator missing", ErrMalformedHeader)
+		}
+
+		// line is either listener or session start, never both: listener line decides language
+		if listener, languageCode, ok := matchListener(line); ok && !haveListener {
+			h.Listener, h.LanguageCode, haveListener = listener, languageCode, true
+			continue
+		}
+
+		if session == "" && matchesSessionStart(line) {
+			session = line
+		}
+	}
+
+	if !haveListener {
+		return nil, fmt.Errorf("%w: listener missing", ErrMalformedHeader)
+	}
+
+	languagesMu.RLock()
+	sessionStartRe := LanguageMatchers[h.LanguageCode].SessionStartRe
+	languagesMu.RUnlock()
+
+	matches := sessionStartRe.FindStringSubmatch(session)
+	if matches == nil {
+		return nil, fmt.Errorf("%w: session start missing", ErrMalformedHeader)
+	}
+
+	started, err := time.Parse(DateFormat, strings.TrimSpace(matches[1]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: session start: %v", ErrMalformedHeader, err)
+	}
+
+	h.SessionStarted = started
+
+	return h, nil
+}
+
+// ParseHeaderFile parses banner of gamelog file.
+func ParseHeaderFile(filename string) (*Header, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseHeader(file)
+}
+
+func matchListener(line string) (string, LanguageCode, bool) {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+
+	for languageCode, matchers := range LanguageMatchers {
+		if matches := matchers.ListenerRe.FindStringSubmatch(line); matches != nil {
+			return strings.TrimSpace(matches[1]), languageCode, true
+		}
+	}
+
+	return "", LanguageCode_ENGLISH, false
+}
+
+func matchesSessionStart(line string) bool {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+
+	for _, matchers := range LanguageMatchers {
+		if matchers.SessionStartRe.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,130 @@
+package combatlog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseHeader_Testdata(t *testing.T) {
+	tests := []struct {
+		file         string
+		listener     string
+		session      time.Time
+		languageCode LanguageCode
+		wantErr      error
+	}{
+		{"20201022_205944.txt", "Runner1", time.Date(2020, 10, 22, 20, 59, 44, 0, time.UTC), LanguageCode_ENGLISH, nil},
+		{"20201022_205944_french.txt", "French", time.Date(2020, 10, 22, 22, 25, 59, 0, time.UTC), LanguageCode_FRENCH, nil},
+		{"20201022_205944_german.txt", "German", time.Date(2020, 10, 22, 22, 25, 59, 0, time.UTC), LanguageCode_GERMAN, nil},
+		{"20201022_205944_korean.txt", "Korean", time.Date(2020, 10, 22, 22, 25, 59, 0, time.UTC), LanguageCode_KOREAN, nil},
+		{"20201022_205944_spanish.txt", "Spanish", time.Date(2022, 11, 29, 21, 47, 56, 0, time.UTC), LanguageCode_SPANISH, nil},
+		{"20201022_222559.txt", "", time.Time{}, LanguageCode_ENGLISH, ErrMalformedHeader},
+		{"20201022_222614.txt", "Runner2", time.Date(2020, 10, 22, 22, 26, 14, 0, time.UTC), LanguageCode_ENGLISH, nil},
+		{"20201022_222614_newer.txt", "Runner2", time.Date(2020, 10, 23, 22, 26, 14, 0, time.UTC), LanguageCode_ENGLISH, nil},
+	}
+
+	files, _ := filepath.Glob(filepath.Join("testdata", "*.txt"))
+	if len(files) != len(tests) {
+		t.Fatalf("testdata has %d files, %d covered by test", len(files), len(tests))
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			filename := filepath.Join("testdata", tt.file)
+
+			got, err := ParseHeaderFile(filename)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseHeaderFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.Listener != tt.listener || !got.SessionStarted.Equal(tt.session) || got.LanguageCode != tt.languageCode {
+				t.Errorf("ParseHeaderFile() = %+v, want %s, %s, %s", got, tt.listener, tt.session, tt.languageCode)
+			}
+
+			data, _ := os.ReadFile(filename)
+			if !strings.HasSuffix(string(data[:got.Length]), headerSeparator+"\n") {
+				t.Errorf("Header.Length = %d doesn't end at closing separator", got.Length)
+			}
+		})
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	const sep = headerSeparator
+
+	tests := []struct {
+		name     string
+		input    string
+		listener string
+		length   int64
+		wantErr  error
+	}{
+		{
+			name:     "byte order mark and CRLF",
+			input:    "\ufeff" + sep + "\r\n  Gamelog\r\n  Listener: Runner1\r\n  Session Started: 2020.10.22 20:59:44\r\n" + sep + "\r\n[ 2020.10.22 20:59:46 ] (hint) x\r\n",
+			listener: "Runner1",
+			length:   int64(len("\ufeff" + sep + "\r\n  Gamelog\r\n  Listener: Runner1\r\n  Session Started: 2020.10.22 20:59:44\r\n" + sep + "\r\n")),
+		},
+		{
+			name:     "listener line looking like session start",
+			input:    sep + "\n  Listener: Session Started: 2020.10.22 20:59:44\n  Session Started: 2020.10.22 21:00:00\n" + sep,
+			listener: "Session Started: 2020.10.22 20:59:44",
+			length:   int64(len(sep + "\n  Listener: Session Started: 2020.10.22 20:59:44\n  Session Started: 2020.10.22 21:00:00\n" + sep)),
+		},
+		{
+			name:    "empty",
+			input:   "",
+			wantErr: ErrNoHeader,
+		},
+		{
+			name:    "no banner",
+			input:   "[ 2020.10.22 20:59:46 ] (hint) Attempting to join a channel\n",
+			wantErr: ErrNoHeader,
+		},
+		{
+			name:    "closing separator missing",
+			input:   sep + "\n  Gamelog\n  Listener: Runner1\n  Session Started: 2020.10.22 20:59:44\n",
+			wantErr: ErrMalformedHeader,
+		},
+		{
+			name:    "session start missing",
+			input:   sep + "\n  Gamelog\n  Listener: Runner1\n" + sep + "\n",
+			wantErr: ErrMalformedHeader,
+		},
+		{
+			name:    "invalid session start",
+			input:   sep + "\n  Listener: Runner1\n  Session Started: yesterday\n" + sep + "\n",
+			wantErr: ErrMalformedHeader,
+		},
+		{
+			name:    "session start in other language than listener",
+			input:   sep + "\n  Listener: Runner1\n  Sitzung gestartet: 2020.10.22 22:25:59\n" + sep + "\n",
+			wantErr: ErrMalformedHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHeader(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseHeader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.Listener != tt.listener || got.Length != tt.length {
+				t.Errorf("ParseHeader() = %+v, want listener %q, length %d", got, tt.listener, tt.length)
+			}
+		})
+	}
+}
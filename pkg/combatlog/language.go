@@ -1,61 +1,241 @@
 package combatlog
 
-import "regexp"
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"unicode"
+)
 
 //go:generate protoc -I ../../protobuf/ --go_opt=paths=source_relative --go_out=. combatlog.proto
 
-// LanguageMatchers holds default initialized regexps for all languages.
+// FirstRegisteredLanguageCode is first language code available for languages registered at runtime, lower codes are defined in combatlog.proto.
+const FirstRegisteredLanguageCode LanguageCode = 100
+
+// ErrInvalidLanguage returned when language data can't be registered.
+var ErrInvalidLanguage = errors.New("invalid language data")
+
+//go:embed languages.json
+var languagesJSON []byte
+
+// LanguageMatchers holds default initialized regexps for all languages, RegisterLanguage modifies it under languagesMu.
 var LanguageMatchers LocalizedMatchers
 
+// languagesMu guards LanguageMatchers, grammars and languageNames.
+var languagesMu sync.RWMutex
+
 func init() {
 	LanguageMatchers = make(LocalizedMatchers)
+	grammars = make(map[LanguageCode]Grammar)
+	languageNames = make(map[LanguageCode]string)
 
-	LanguageMatchers[LanguageCode_ENGLISH] = LocalizedMatcher{
-		ListenerRe:     regexp.MustCompile(`(?m)^\s*Listener:\s(.*)$`),
-		SessionStartRe: regexp.MustCompile(`(?m)^\s*Session Started:\s(.*)$`),
+	languages, err := parseLanguages(languagesJSON)
+	if err != nil {
+		panic(err)
 	}
 
-	LanguageMatchers[LanguageCode_FRENCH] = LocalizedMatcher{
-		ListenerRe:     regexp.MustCompile(`(?m)^\s*Auditeur:\s(.*)$`),
-		SessionStartRe: regexp.MustCompile(`(?m)^\s*Session commencée:\s(.*)$`),
+	for _, data := range languages {
+		if err = register(data); err != nil {
+			panic(err)
+		}
 	}
+}
 
-	LanguageMatchers[LanguageCode_GERMAN] = LocalizedMatcher{
-		ListenerRe:     regexp.MustCompile(`(?m)^\s*Empfänger:\s(.*)$`),
-		SessionStartRe: regexp.MustCompile(`(?m)^\s*Sitzung gestartet:\s(.*)$`),
+// LocalizedMatcher holds regexps for language to detect.
+type LocalizedMatcher struct {
+	ListenerRe     *regexp.Regexp
+	SessionStartRe *regexp.Regexp
+}
+
+// LocalizedMatchers mapping between LanguageCode and LocalizedMatchers.
+type LocalizedMatchers map[LanguageCode]LocalizedMatcher
+
+// LanguageData describes how client writes gamelogs in single language.
+type LanguageData struct {
+	Code LanguageCode `json:"code"`
+	Name string       `json:"name"`
+	// Listener and SessionStarted are labels of gamelog banner lines.
+	Listener       string `json:"listener"`
+	SessionStarted string `json:"session_started"`
+	// To and From are words damage lines point to target or source with.
+	To   string `json:"to,omitempty"`
+	From string `json:"from,omitempty"`
+	// Qualities maps localized hit quality to english one.
+	Qualities map[string]string `json:"qualities,omitempty"`
+	// IncomingMiss, OutgoingMiss and WarpScramble are regular expressions, see Grammar.
+	IncomingMiss string   `json:"incoming_miss,omitempty"`
+	OutgoingMiss string   `json:"outgoing_miss,omitempty"`
+	WarpScramble string   `json:"warp_scramble,omitempty"`
+	Notify       []string `json:"notify,omitempty"`
+	// Scripts are names of unicode scripts (unicode.Scripts) language is written in, only for non-latin languages.
+	Scripts []string `json:"scripts,omitempty"`
+	// SampleBanner is banner of gamelog written in language.
+	SampleBanner string `json:"sample_banner,omitempty"`
+}
+
+// RegisterLanguage adds language or replaces existing one, languages not defined in combatlog.proto
+// must use codes from FirstRegisteredLanguageCode up. Languages can be registered while gamelogs are read.
+func RegisterLanguage(data LanguageData) error {
+	if data.Code < FirstRegisteredLanguageCode {
+		if _, defined := LanguageCode_name[int32(data.Code)]; !defined || data.Code == LanguageCode_UNKNOWN {
+			return fmt.Errorf("%w: code %d is reserved", ErrInvalidLanguage, data.Code)
+		}
 	}
 
-	LanguageMatchers[LanguageCode_RUSSIAN] = LocalizedMatcher{
-		ListenerRe:     regexp.MustCompile(`(?m)^\s*Слушатель:\s(.*)$`),
-		SessionStartRe: regexp.MustCompile(`(?m)^\s*Сеанс начат:\s(.*)$`),
+	return register(data)
+}
+
+// RegisterLanguages registers languages read from JSON array of LanguageData.
+func RegisterLanguages(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
 	}
 
-	LanguageMatchers[LanguageCode_JAPANESE] = LocalizedMatcher{
-		ListenerRe:     regexp.MustCompile(`(?m)^\s*傍聴者:\s(.*)$`),
-		SessionStartRe: regexp.MustCompile(`(?m)^\s*セッション開始:\s(.*)$`),
+	languages, err := parseLanguages(data)
+	if err != nil {
+		return err
 	}
 
-	LanguageMatchers[LanguageCode_KOREAN] = LocalizedMatcher{
-		ListenerRe:     regexp.MustCompile(`(?m)^\s*청취자:\s(.*)$`),
-		SessionStartRe: regexp.MustCompile(`(?m)^\s*세션 시작됨:\s(.*)$`),
+	for _, language := range languages {
+		if err = RegisterLanguage(language); err != nil {
+			return err
+		}
 	}
 
-	LanguageMatchers[LanguageCode_CHINESE] = LocalizedMatcher{
-		ListenerRe:     regexp.MustCompile(`(?m)^\s*收听者:\s(.*)$`),
-		SessionStartRe: regexp.MustCompile(`(?m)^\s*进程开始:\s(.*)$`),
+	return nil
+}
+
+// Languages returns codes of all known languages, in order.
+func Languages() []LanguageCode {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+
+	return languageCodes()
+}
+
+// LanguageName returns name of language, name from combatlog.proto for languages not registered.
+func LanguageName(code LanguageCode) string {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+
+	if name, ok := languageNames[code]; ok {
+		return name
 	}
 
-	LanguageMatchers[LanguageCode_SPANISH] = LocalizedMatcher{
-		ListenerRe:     regexp.MustCompile(`(?m)^\s*Oyente:\s(.*)$`),
-		SessionStartRe: regexp.MustCompile(`(?m)^\s*Sesión iniciada:\s(.*)$`),
+	return code.String()
+}
+
+var languageNames map[LanguageCode]string
+
+func parseLanguages(data []byte) ([]LanguageData, error) {
+	var languages []LanguageData
+
+	if err := json.Unmarshal(data, &languages); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLanguage, err)
 	}
+
+	return languages, nil
 }
 
-// LocalizedMatcher holds regexps for language to detect.
-type LocalizedMatcher struct {
-	ListenerRe     *regexp.Regexp
-	SessionStartRe *regexp.Regexp
+func register(data LanguageData) error {
+	if data.Listener == "" || data.SessionStarted == "" {
+		return fmt.Errorf("%w: %s: banner labels missing", ErrInvalidLanguage, data.Name)
+	}
+
+	g := Grammar{To: data.To, From: data.From, Notify: data.Notify}
+
+	if len(data.Qualities) > 0 {
+		g.Qualities = make(map[string]HitQuality, len(data.Qualities))
+	}
+
+	for localized, english := range data.Qualities {
+		quality, ok := parseHitQuality(english)
+		if !ok {
+			return fmt.Errorf("%w: %s: unknown hit quality %q", ErrInvalidLanguage, data.Name, english)
+		}
+
+		g.Qualities[localized] = quality
+	}
+
+	for _, re := range []struct {
+		expr   string
+		target **regexp.Regexp
+	}{
+		{data.IncomingMiss, &g.IncomingMissRe},
+		{data.OutgoingMiss, &g.OutgoingMissRe},
+		{data.WarpScramble, &g.WarpScrambleRe},
+	} {
+		if re.expr == "" {
+			continue
+		}
+
+		compiled, err := regexp.Compile(re.expr)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidLanguage, data.Name, err)
+		}
+
+		*re.target = compiled
+	}
+
+	for _, script := range data.Scripts {
+		table, ok := unicode.Scripts[script]
+		if !ok {
+			return fmt.Errorf("%w: %s: unknown script %q", ErrInvalidLanguage, data.Name, script)
+		}
+
+		g.Scripts = append(g.Scripts, table)
+	}
+
+	matcher := LocalizedMatcher{
+		ListenerRe:     bannerRe(data.Listener),
+		SessionStartRe: bannerRe(data.SessionStarted),
+	}
+
+	languagesMu.Lock()
+	defer languagesMu.Unlock()
+
+	LanguageMatchers[data.Code] = matcher
+	grammars[data.Code] = g
+	languageNames[data.Code] = data.Name
+
+	return nil
 }
 
-// LocalizedMatchers mapping between LanguageCode and LocalizedMatchers.
-type LocalizedMatchers map[LanguageCode]LocalizedMatcher
+// grammar returns grammar of language.
+func grammar(languageCode LanguageCode) (Grammar, bool) {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+
+	g, ok := grammars[languageCode]
+
+	return g, ok
+}
+
+// RecordedLanguageCode returns language code stored in recordings. Languages registered at runtime
+// are not known outside of this application, recordings store them as LanguageCode_UNKNOWN.
+func RecordedLanguageCode(languageCode LanguageCode) LanguageCode {
+	if languageCode >= FirstRegisteredLanguageCode {
+		return LanguageCode_UNKNOWN
+	}
+
+	return languageCode
+}
+
+func bannerRe(label string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(label) + `:\s(.*)$`)
+}
+
+func parseHitQuality(name string) (HitQuality, bool) {
+	for q := HitMiss; q <= HitWrecks; q++ {
+		if q.String() == name {
+			return q, true
+		}
+	}
+
+	return HitUnknown, false
+}
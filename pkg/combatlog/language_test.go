@@ -0,0 +1,130 @@
+package combatlog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLanguages_SampleBanners(t *testing.T) {
+	languages, err := parseLanguages(languagesJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for code := range LanguageCode_name {
+		if code == int32(LanguageCode_UNKNOWN) {
+			continue
+		}
+
+		if _, ok := grammars[LanguageCode(code)]; !ok {
+			t.Errorf("language %s from combatlog.proto is not registered", LanguageCode(code))
+		}
+	}
+
+	for _, data := range languages {
+		t.Run(data.Name, func(t *testing.T) {
+			header, err := ParseHeader(strings.NewReader(data.SampleBanner))
+			if err != nil {
+				t.Fatalf("ParseHeader() error = %v", err)
+			}
+
+			if header.LanguageCode != data.Code || header.Listener != data.Name {
+				t.Errorf("ParseHeader() = %s, %q, want %s, %q", header.LanguageCode, header.Listener, data.Code, data.Name)
+			}
+		})
+	}
+}
+
+func TestRegisterLanguage(t *testing.T) {
+	italian := LanguageData{
+		Code:           FirstRegisteredLanguageCode,
+		Name:           "Italian",
+		Listener:       "Ascoltatore",
+		SessionStarted: "Sessione iniziata",
+		To:             "a",
+		From:           "da",
+		Qualities:      map[string]string{"Colpisce": "Hits"},
+		IncomingMiss:   `^(.+) ti manca completamente`,
+	}
+
+	t.Cleanup(func() {
+		delete(LanguageMatchers, italian.Code)
+		delete(grammars, italian.Code)
+		delete(languageNames, italian.Code)
+	})
+
+	tests := []struct {
+		name    string
+		data    func(LanguageData) LanguageData
+		wantErr error
+	}{
+		{name: "reserved code", data: func(d LanguageData) LanguageData { d.Code = 42; return d }, wantErr: ErrInvalidLanguage},
+		{name: "unknown code", data: func(d LanguageData) LanguageData { d.Code = LanguageCode_UNKNOWN; return d }, wantErr: ErrInvalidLanguage},
+		{name: "banner labels missing", data: func(d LanguageData) LanguageData { d.Listener = ""; return d }, wantErr: ErrInvalidLanguage},
+		{name: "unknown hit quality", data: func(d LanguageData) LanguageData { d.Qualities = map[string]string{"Colpisce": "Bonks"}; return d }, wantErr: ErrInvalidLanguage},
+		{name: "invalid regexp", data: func(d LanguageData) LanguageData { d.OutgoingMiss = "(("; return d }, wantErr: ErrInvalidLanguage},
+		{name: "unknown script", data: func(d LanguageData) LanguageData { d.Scripts = []string{"Klingon"}; return d }, wantErr: ErrInvalidLanguage},
+		{name: "registered", data: func(d LanguageData) LanguageData { return d }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterLanguage(tt.data(italian)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("RegisterLanguage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	banner := headerSeparator + "\n  Registro di gioco\n  Ascoltatore: Runner1\n  Sessione iniziata: 2020.10.22 20:59:44\n" + headerSeparator + "\n"
+
+	header, err := ParseHeader(strings.NewReader(banner))
+	if err != nil || header.LanguageCode != italian.Code || LanguageName(header.LanguageCode) != "Italian" {
+		t.Fatalf("ParseHeader() = %+v, %v", header, err)
+	}
+
+	e := NewParser(italian.Code).ParseLine("[ 2020.10.22 21:01:28 ] (combat) Gistii Ambusher ti manca completamente")
+	if e.Type != EventMiss || e.Source != "Gistii Ambusher" {
+		t.Errorf("ParseLine() with registered grammar = %+v", e)
+	}
+}
+
+func TestRegisterLanguage_WhileReading(t *testing.T) {
+	data := LanguageData{Code: FirstRegisteredLanguageCode + 1, Name: "Italian", Listener: "Ascoltatore", SessionStarted: "Sessione iniziata"}
+
+	t.Cleanup(func() {
+		delete(LanguageMatchers, data.Code)
+		delete(grammars, data.Code)
+		delete(languageNames, data.Code)
+	})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 100; i++ {
+			if err := RegisterLanguage(data); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	lines := repeatLines(5, frenchDamage, germanMiss)
+
+	for i := 0; i < 100; i++ {
+		if _, err := ParseHeader(strings.NewReader(headerSeparator + "\n  Listener: Runner1\n  Session Started: 2020.10.22 20:59:44\n" + headerSeparator + "\n")); err != nil {
+			t.Fatal(err)
+		}
+
+		DetectLanguage(lines)
+		Normalize(frenchDamage, LanguageCode_FRENCH)
+	}
+
+	<-done
+
+	if got := RecordedLanguageCode(data.Code); got != LanguageCode_UNKNOWN {
+		t.Errorf("RecordedLanguageCode(%s) = %s, want UNKNOWN", LanguageName(data.Code), got)
+	}
+}
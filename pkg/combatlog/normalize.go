@@ -0,0 +1,107 @@
+package combatlog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Normalize rewrites gamelog line written by client in given language into canonical english form,
+// so logs of all languages can be processed as english ones. Lines not understood are returned unchanged.
+func Normalize(line string, languageCode LanguageCode) string {
+	g, ok := grammar(languageCode)
+	if !ok || languageCode == LanguageCode_ENGLISH {
+		return line
+	}
+
+	m := lineRe.FindStringSubmatch(strings.TrimRight(line, "\r"))
+	if m == nil || Category(m[2]) != CategoryCombat {
+		return line
+	}
+
+	prefix := fmt.Sprintf("[ %s ] (%s) ", m[1], m[2])
+	body, text := m[3], stripMarkup(m[3])
+	english, _ := grammar(LanguageCode_ENGLISH)
+
+	if normalized, ok := g.normalizeDamage(body, english); ok {
+		return prefix + normalized
+	}
+
+	if mm := findSubmatch(g.IncomingMissRe, text); mm != nil {
+		return prefix + fmt.Sprintf("%s misses you completely", strings.TrimSpace(mm[1]))
+	}
+
+	if mm := findSubmatch(g.OutgoingMissRe, text); mm != nil {
+		return prefix + fmt.Sprintf("Your %s misses %s completely", strings.TrimSpace(mm[1]), strings.TrimSpace(mm[2]))
+	}
+
+	if mm := findSubmatch(g.WarpScrambleRe, text); mm != nil {
+		return prefix + fmt.Sprintf("Warp scramble attempt from %s to %s!", strings.TrimSpace(mm[1]), strings.TrimSpace(mm[2]))
+	}
+
+	return line
+}
+
+// NormalizeRecord returns copy of record with lines normalized to english.
+// Language of record is detected from its lines, language stored in record is used when detection is not confident.
+func NormalizeRecord(record *CombatLogRecord) *CombatLogRecord {
+	languageCode := DetectLanguage(record.GetCombatLogLines()).Refine(record.GetLanguageCode())
+
+	normalized := &CombatLogRecord{
+		CharacterName:  record.GetCharacterName(),
+		CombatLogLines: make([]string, len(record.GetCombatLogLines())),
+		LanguageCode:   LanguageCode_ENGLISH,
+	}
+
+	for i, line := range record.GetCombatLogLines() {
+		normalized.CombatLogLines[i] = Normalize(line, languageCode)
+	}
+
+	return normalized
+}
+
+// normalizeDamage replaces localized direction word and hit quality of damage line body with english ones.
+func (g Grammar) normalizeDamage(body string, english Grammar) (string, bool) {
+	idx := damageRe.FindStringSubmatchIndex(body)
+	if idx == nil {
+		return "", false
+	}
+
+	var word string
+
+	// languages without direction words in grammar are told by amount color, as parser does
+	direction := g.direction(strings.TrimSpace(body[idx[6]:idx[7]]))
+	if direction == DirectionNone {
+		direction = colorDirection(body[idx[2]:idx[3]])
+	}
+
+	switch direction {
+	case DirectionOutgoing:
+		word = english.To
+	case DirectionIncoming:
+		word = english.From
+	default:
+		return "", false
+	}
+
+	tail := body[idx[10]:idx[11]]
+	parts := strings.Split(markupRe.ReplaceAllString(tail, ""), " - ")
+	name := strings.TrimSpace(parts[len(parts)-1])
+
+	if quality, ok := g.Qualities[name]; ok {
+		if englishName, ok := english.quality(quality); ok {
+			i := strings.LastIndex(tail, name)
+			tail = tail[:i] + englishName + tail[i+len(name):]
+		}
+	}
+
+	return body[:idx[6]] + word + body[idx[7]:idx[10]] + tail, true
+}
+
+func findSubmatch(re *regexp.Regexp, s string) []string {
+	if re == nil {
+		return nil
+	}
+
+	return re.FindStringSubmatch(s)
+}
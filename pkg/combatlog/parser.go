@@ -0,0 +1,306 @@
+package combatlog
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Category is the channel tag gamelog line was written with, e.g. "combat" or "notify".
+type Category string
+
+const (
+	CategoryNone     Category = ""
+	CategoryCombat   Category = "combat"
+	CategoryNotify   Category = "notify"
+	CategoryHint     Category = "hint"
+	CategoryWarning  Category = "warning"
+	CategoryInfo     Category = "info"
+	CategoryBounty   Category = "bounty"
+	CategoryQuestion Category = "question"
+	CategoryMining   Category = "mining"
+)
+
+// EventType tells what kind of information parser was able to extract from line.
+type EventType int
+
+const (
+	// EventUnparsed line was not understood by parser, only Raw (and Timestamp/Category if present) is filled.
+	EventUnparsed EventType = iota
+	// EventMessage is timestamped non-combat line (notify, hint, bounty...), Text holds message.
+	EventMessage
+	// EventDamage is combat line with damage amount.
+	EventDamage
+	// EventMiss is combat line describing missed shot.
+	EventMiss
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventMessage:
+		return "message"
+	case EventDamage:
+		return "damage"
+	case EventMiss:
+		return "miss"
+	default:
+		return "unparsed"
+	}
+}
+
+// Direction of combat event relative to owner of gamelog.
+type Direction int
+
+const (
+	DirectionNone Direction = iota
+	DirectionOutgoing
+	DirectionIncoming
+)
+
+func (d Direction) String() string {
+	switch d {
+	case DirectionOutgoing:
+		return "outgoing"
+	case DirectionIncoming:
+		return "incoming"
+	default:
+		return "none"
+	}
+}
+
+// HitQuality of weapon hit as reported by game client.
+type HitQuality int
+
+const (
+	HitUnknown HitQuality = iota
+	HitMiss
+	HitGlancesOff
+	HitGrazes
+	HitHits
+	HitPenetrates
+	HitSmashes
+	HitWrecks
+)
+
+func (q HitQuality) String() string {
+	switch q {
+	case HitMiss:
+		return "Misses"
+	case HitGlancesOff:
+		return "Glances Off"
+	case HitGrazes:
+		return "Grazes"
+	case HitHits:
+		return "Hits"
+	case HitPenetrates:
+		return "Penetrates"
+	case HitSmashes:
+		return "Smashes"
+	case HitWrecks:
+		return "Wrecks"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is single parsed gamelog line.
+type Event struct {
+	Type      EventType
+	Timestamp time.Time
+	Category  Category
+	Direction Direction
+	Amount    int
+	Quality   HitQuality
+	Source    string
+	Target    string
+	Weapon    string
+	Text      string // line body with markup removed
+	Raw       string
+}
+
+var (
+	lineRe   = regexp.MustCompile(`^\[ (\d{4}\.\d{2}\.\d{2} \d{2}:\d{2}:\d{2}) \] \(([a-z]+)\) ?(.*)$`)
+	damageRe = regexp.MustCompile(`^<color=(0x[0-9a-fA-F]{8})><b>(\d+)</b>.*?<font size=\d+>([^<]*)</font>\s*<b>(?:<color=0x[0-9a-fA-F]{8}>)?([^<]*)</b>(.*)$`)
+	markupRe = regexp.MustCompile(`<[^>]*>`)
+)
+
+// colors client uses for damage amounts, used when direction word is not known for language.
+const (
+	outgoingColor = "0xff00ffff"
+	incomingColor = "0xffcc0000"
+)
+
+// colorDirection returns direction of damage by color of amount.
+func colorDirection(color string) Direction {
+	switch strings.ToLower(color) {
+	case outgoingColor:
+		return DirectionOutgoing
+	case incomingColor:
+		return DirectionIncoming
+	default:
+		return DirectionNone
+	}
+}
+
+// Parser converts gamelog lines into Events using grammar of given language.
+type Parser struct {
+	grammar Grammar
+}
+
+// NewParser returns parser for language, falling back to english grammar for languages without one.
+func NewParser(languageCode LanguageCode) *Parser {
+	g, ok := grammar(languageCode)
+	if !ok {
+		g, _ = grammar(LanguageCode_ENGLISH)
+	}
+
+	return &Parser{grammar: g}
+}
+
+// ParseRecord parses all lines of CombatLogRecord, filling recording character as source or target of combat events.
+func ParseRecord(record *CombatLogRecord) []*Event {
+	p := NewParser(record.GetLanguageCode())
+	events := make([]*Event, 0, len(record.GetCombatLogLines()))
+
+	for _, line := range record.GetCombatLogLines() {
+		e := p.ParseLine(line)
+
+		if e.Type == EventDamage || e.Type == EventMiss {
+			switch e.Direction {
+			case DirectionOutgoing:
+				e.Source = record.GetCharacterName()
+			case DirectionIncoming:
+				e.Target = record.GetCharacterName()
+			}
+		}
+
+		events = append(events, e)
+	}
+
+	return events
+}
+
+// ParseLine parses single gamelog line. Lines parser doesn't understand are returned as EventUnparsed, never dropped.
+func (p *Parser) ParseLine(line string) *Event {
+	e := &Event{Type: EventUnparsed, Raw: line}
+
+	matches := lineRe.FindStringSubmatch(strings.TrimRight(line, "\r"))
+	if matches == nil {
+		return e
+	}
+
+	ts, err := time.Parse(DateFormat, matches[1])
+	if err != nil {
+		return e
+	}
+
+	e.Timestamp = ts
+	e.Category = Category(matches[2])
+	e.Text = stripMarkup(matches[3])
+
+	if e.Category != CategoryCombat {
+		e.Type = EventMessage
+		return e
+	}
+
+	// combat lines not understood stay EventUnparsed, but keep timestamp and category
+	if !p.parseDamage(e, matches[3]) {
+		p.parseMiss(e)
+	}
+
+	return e
+}
+
+func (p *Parser) parseDamage(e *Event, body string) bool {
+	m := damageRe.FindStringSubmatch(body)
+	if m == nil {
+		return false
+	}
+
+	amount, err := strconv.Atoi(m[2])
+	if err != nil {
+		return false
+	}
+
+	direction := p.grammar.direction(strings.TrimSpace(m[3]))
+	if direction == DirectionNone {
+		if direction = colorDirection(m[1]); direction == DirectionNone {
+			return false
+		}
+	}
+
+	other := strings.TrimSpace(m[4])
+
+	// remaining part is " - weapon - quality" or just " - quality"
+	var parts []string
+
+	for _, part := range strings.Split(markupRe.ReplaceAllString(m[5], ""), " - ") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	if len(parts) > 0 {
+		e.Quality = p.grammar.Qualities[parts[len(parts)-1]]
+	}
+
+	if len(parts) > 1 {
+		e.Weapon = strings.Join(parts[:len(parts)-1], " - ")
+	}
+
+	e.Type = EventDamage
+	e.Amount = amount
+	e.Direction = direction
+
+	if direction == DirectionOutgoing {
+		e.Target = other
+	} else {
+		e.Source = other
+	}
+
+	return true
+}
+
+func (p *Parser) parseMiss(e *Event) bool {
+	if p.grammar.IncomingMissRe != nil {
+		if m := p.grammar.IncomingMissRe.FindStringSubmatch(e.Text); m != nil {
+			e.Type = EventMiss
+			e.Quality = HitMiss
+			e.Direction = DirectionIncoming
+			e.Source = strings.TrimSpace(m[1])
+
+			return true
+		}
+	}
+
+	if p.grammar.OutgoingMissRe != nil {
+		if m := p.grammar.OutgoingMissRe.FindStringSubmatch(e.Text); m != nil {
+			e.Type = EventMiss
+			e.Quality = HitMiss
+			e.Direction = DirectionOutgoing
+			e.Weapon = strings.TrimSpace(m[1])
+			e.Target = strings.TrimSpace(m[2])
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// lineTimestamp returns timestamp of gamelog line, zero time if line has none.
+func lineTimestamp(line string) time.Time {
+	matches := lineRe.FindStringSubmatch(line)
+	if matches == nil {
+		return time.Time{}
+	}
+
+	ts, _ := time.Parse(DateFormat, matches[1])
+
+	return ts
+}
+
+func stripMarkup(s string) string {
+	return strings.TrimSpace(markupRe.ReplaceAllString(s, ""))
+}
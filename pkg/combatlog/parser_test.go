@@ -0,0 +1,199 @@
+package combatlog
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParser_ParseLine(t *testing.T) {
+	p := NewParser(LanguageCode_ENGLISH)
+
+	tests := []struct {
+		name string
+		line string
+		want Event
+	}{
+		{
+			name: "outgoing damage",
+			line: "[ 2020.10.22 21:00:57 ] (combat) <color=0xff00ffff><b>180</b> <color=0x77ffffff><font size=10>to</font> <b><color=0xffffffff>Gistii Rogue</b><font size=10><color=0x77ffffff> - Veles Light Entropic Disintegrator - Penetrates",
+			want: Event{
+				Type:      EventDamage,
+				Timestamp: time.Date(2020, 10, 22, 21, 0, 57, 0, time.UTC),
+				Category:  CategoryCombat,
+				Direction: DirectionOutgoing,
+				Amount:    180,
+				Quality:   HitPenetrates,
+				Target:    "Gistii Rogue",
+				Weapon:    "Veles Light Entropic Disintegrator",
+				Text:      "180 to Gistii Rogue - Veles Light Entropic Disintegrator - Penetrates",
+			},
+		},
+		{
+			name: "incoming damage with weapon",
+			line: "[ 2020.10.22 21:01:33 ] (combat) <color=0xffcc0000><b>11</b> <color=0x77ffffff><font size=10>from</font> <b><color=0xffffffff>Gistii Ambusher</b><font size=10><color=0x77ffffff> - Nova Light Missile - Hits",
+			want: Event{
+				Type:      EventDamage,
+				Timestamp: time.Date(2020, 10, 22, 21, 1, 33, 0, time.UTC),
+				Category:  CategoryCombat,
+				Direction: DirectionIncoming,
+				Amount:    11,
+				Quality:   HitHits,
+				Source:    "Gistii Ambusher",
+				Weapon:    "Nova Light Missile",
+				Text:      "11 from Gistii Ambusher - Nova Light Missile - Hits",
+			},
+		},
+		{
+			name: "incoming damage without weapon",
+			line: "[ 2020.10.22 21:01:43 ] (combat) <color=0xffcc0000><b>4</b> <color=0x77ffffff><font size=10>from</font> <b><color=0xffffffff>Gistii Ambusher</b><font size=10><color=0x77ffffff> - Glances Off",
+			want: Event{
+				Type:      EventDamage,
+				Timestamp: time.Date(2020, 10, 22, 21, 1, 43, 0, time.UTC),
+				Category:  CategoryCombat,
+				Direction: DirectionIncoming,
+				Amount:    4,
+				Quality:   HitGlancesOff,
+				Source:    "Gistii Ambusher",
+				Text:      "4 from Gistii Ambusher - Glances Off",
+			},
+		},
+		{
+			name: "incoming miss",
+			line: "[ 2020.10.22 21:01:28 ] (combat) Gistii Ambusher misses you completely",
+			want: Event{
+				Type:      EventMiss,
+				Timestamp: time.Date(2020, 10, 22, 21, 1, 28, 0, time.UTC),
+				Category:  CategoryCombat,
+				Direction: DirectionIncoming,
+				Quality:   HitMiss,
+				Source:    "Gistii Ambusher",
+				Text:      "Gistii Ambusher misses you completely",
+			},
+		},
+		{
+			name: "outgoing miss",
+			line: "[ 2020.10.22 21:01:29 ] (combat) Your Veles Light Entropic Disintegrator misses Gistii Rogue completely - Veles Light Entropic Disintegrator",
+			want: Event{
+				Type:      EventMiss,
+				Timestamp: time.Date(2020, 10, 22, 21, 1, 29, 0, time.UTC),
+				Category:  CategoryCombat,
+				Direction: DirectionOutgoing,
+				Quality:   HitMiss,
+				Target:    "Gistii Rogue",
+				Weapon:    "Veles Light Entropic Disintegrator",
+				Text:      "Your Veles Light Entropic Disintegrator misses Gistii Rogue completely - Veles Light Entropic Disintegrator",
+			},
+		},
+		{
+			name: "notify",
+			line: "[ 2020.10.22 21:02:19 ] (notify) Ship stopping",
+			want: Event{
+				Type:      EventMessage,
+				Timestamp: time.Date(2020, 10, 22, 21, 2, 19, 0, time.UTC),
+				Category:  CategoryNotify,
+				Text:      "Ship stopping",
+			},
+		},
+		{
+			name: "unknown combat line",
+			line: "[ 2020.10.22 21:02:20 ] (combat) Warp scramble attempt from Gistii Ambusher to you!",
+			want: Event{
+				Type:      EventUnparsed,
+				Timestamp: time.Date(2020, 10, 22, 21, 2, 20, 0, time.UTC),
+				Category:  CategoryCombat,
+				Text:      "Warp scramble attempt from Gistii Ambusher to you!",
+			},
+		},
+		{
+			name: "line without timestamp",
+			line: "NOTE: Attacking members of your fleet is not a CONCORD sanctioned activity.",
+			want: Event{Type: EventUnparsed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.Raw = tt.line
+
+			if got := p.ParseLine(tt.line); !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Parser.ParseLine() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRecord(t *testing.T) {
+	lines := readBody(t, filepath.Join("testdata", "20201022_205944.txt"))
+
+	events := ParseRecord(&CombatLogRecord{CharacterName: "Runner1", CombatLogLines: lines, LanguageCode: LanguageCode_ENGLISH})
+	if len(events) != len(lines) {
+		t.Fatalf("ParseRecord() returned %d events for %d lines", len(events), len(lines))
+	}
+
+	counts := make(map[EventType]int)
+	dealt, received := 0, 0
+
+	for _, e := range events {
+		counts[e.Type]++
+
+		if e.Type != EventDamage {
+			continue
+		}
+
+		if e.Direction == DirectionOutgoing {
+			if e.Source != "Runner1" || e.Quality == HitUnknown {
+				t.Errorf("unexpected outgoing event: %+v", e)
+			}
+
+			dealt += e.Amount
+		} else {
+			if e.Target != "Runner1" || e.Quality == HitUnknown {
+				t.Errorf("unexpected incoming event: %+v", e)
+			}
+
+			received += e.Amount
+		}
+	}
+
+	want := map[EventType]int{EventDamage: 93, EventMiss: 7, EventMessage: 36, EventUnparsed: 13}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("ParseRecord() event counts = %v, want %v", counts, want)
+	}
+
+	if dealt != 15571 || received != 352 {
+		t.Errorf("ParseRecord() dealt = %d, received = %d", dealt, received)
+	}
+}
+
+// readBody reads gamelog lines skipping banner.
+func readBody(t *testing.T, filename string) []string {
+	t.Helper()
+
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	lines := []string{}
+	separators := 0
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		if separators < 2 {
+			if scanner.Text() == "------------------------------------------------------------" {
+				separators++
+			}
+
+			continue
+		}
+
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines
+}
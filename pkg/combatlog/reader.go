@@ -2,8 +2,10 @@ package combatlog
 
 import (
 	"bufio"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 )
 
@@ -46,6 +48,7 @@ func (r *Reader) GetLogFiles(end time.Time, timeWindow time.Duration) ([]string,
 }
 
 // GetCombatLogRecords reads combatlog from stored offsets and converts to CombatLogRecord struct.
+// Session files started since offsets were marked (character relogged) are stitched into same record.
 func (r *Reader) GetCombatLogRecords(characters map[string]CombatLogFile) []*CombatLogRecord {
 	recordings := make([]*CombatLogRecord, 0)
 
@@ -55,23 +58,10 @@ func (r *Reader) GetCombatLogRecords(characters map[string]CombatLogFile) []*Com
 			continue
 		}
 
-		file, err := os.Open(logfile.Filename)
+		clr, err := r.ReadCombatLogRecordUntil(character, logfile, startFileInfo.Size(), time.Now())
 		if err != nil {
 			continue
 		}
-		defer file.Close()
-
-		clr := &CombatLogRecord{CharacterName: character, CombatLogLines: []string{}, LanguageCode: logfile.LanguageCode}
-
-		_, err = file.Seek(startFileInfo.Size(), 0)
-		if err != nil {
-			continue
-		}
-
-		scanner := bufio.NewScanner(file)
-		for scanner.Scan() {
-			clr.CombatLogLines = append(clr.CombatLogLines, scanner.Text())
-		}
 
 		recordings = append(recordings, clr)
 	}
@@ -81,8 +71,44 @@ func (r *Reader) GetCombatLogRecords(characters map[string]CombatLogFile) []*Com
 	return recordings
 }
 
-// MarkStartOffsets stores offsets of combatlog files.
+// StartOffsets returns offsets marked by MarkStartOffsets, keyed by character.
+func (r *Reader) StartOffsets() map[string]int64 {
+	offsets := make(map[string]int64, len(r.startOffsets))
+
+	for character, fi := range r.startOffsets {
+		offsets[character] = fi.Size()
+	}
+
+	return offsets
+}
+
+// ReadCombatLogRecord reads combatlog file of character from given offset until end of file.
+func ReadCombatLogRecord(character string, logfile CombatLogFile, offset int64) (*CombatLogRecord, error) {
+	file, err := os.Open(logfile.Filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	clr := &CombatLogRecord{CharacterName: character, CombatLogLines: []string{}, LanguageCode: logfile.LanguageCode}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		clr.CombatLogLines = append(clr.CombatLogLines, scanner.Text())
+	}
+
+	return clr, scanner.Err()
+}
+
+// MarkStartOffsets stores offsets of combatlog files, replacing offsets marked before.
 func (r *Reader) MarkStartOffsets(characters map[string]CombatLogFile) {
+	r.startOffsets = make(map[string]os.FileInfo, len(characters))
+
 	for character, logfile := range characters {
 		file, err := os.Open(logfile.Filename)
 		if err != nil {
@@ -101,85 +127,154 @@ func (r *Reader) MarkStartOffsets(characters map[string]CombatLogFile) {
 
 // MapCharactersToFiles maps given paths to characters, detecting combatlog language in process.
 func (r *Reader) MapCharactersToFiles(files []string) map[string]CombatLogFile {
-	type f struct {
-		sessionStarted *time.Time
-		filename       string
-		character      *string
-		languageCode   LanguageCode
-	}
-
-	tempMap := make(map[string]f)
+	tempMap := make(map[string]sessionFile)
 
 	for _, filename := range files {
-		file, err := os.Open(filename)
-		if err != nil {
+		logFile, ok := readSessionHeader(filename)
+		if !ok {
 			continue
 		}
-		defer file.Close()
 
-		reader := bufio.NewScanner(file)
-
-		var insideBanner bool
-
-		logFile := f{filename: filename}
-
-		for reader.Scan() {
-			if reader.Text() == "------------------------------------------------------------" {
-				if !insideBanner {
-					insideBanner = true
-					continue
-				}
-
-				if insideBanner {
-					break // reading closing header separator, just break
-				}
-			}
-
-			if insideBanner {
-				listenerText := reader.Text()
-				sessionText := reader.Text()
-
-				for languageCode, matchers := range LanguageMatchers {
-					if matches := matchers.ListenerRe.FindAllStringSubmatch(listenerText, 1); matches != nil {
-						logFile.character = &matches[0][1]
-						logFile.languageCode = languageCode
-					}
-
-					if matches := matchers.SessionStartRe.FindAllStringSubmatch(sessionText, 1); matches != nil {
-						sessionStart, err := time.Parse(DateFormat, matches[0][1])
-						if err != nil {
-							continue
-						}
-
-						logFile.sessionStarted = &sessionStart
-					}
-				}
-			}
-
-			// if we have all we need from this file exit scan loop
-			if logFile.character != nil && logFile.sessionStarted != nil {
-				val, ok := tempMap[*logFile.character]
-				if ok {
-					if val.sessionStarted.Before(*logFile.sessionStarted) {
-						tempMap[*logFile.character] = logFile
-					}
-				} else {
-					tempMap[*logFile.character] = logFile
-				}
-
-				break
-			}
+		val, ok := tempMap[logFile.character]
+		if !ok || val.sessionStarted.Before(logFile.sessionStarted) {
+			tempMap[logFile.character] = logFile
 		}
 	}
 
 	result := make(map[string]CombatLogFile, len(tempMap))
 	for character, logfile := range tempMap {
-		result[character] = CombatLogFile{Filename: logfile.filename, LanguageCode: logfile.languageCode}
+		result[character] = logfile.CombatLogFile
 	}
 
 	return result
 }
 
+// ReadCombatLogRecordUntil reads combatlog of character from offset in logfile like ReadCombatLogRecord,
+// stitching lines of session files client started for same listener before until (character relogged during run).
+// Lines logged after until are left out, lines are ordered by timestamp.
+func (r *Reader) ReadCombatLogRecordUntil(character string, logfile CombatLogFile, offset int64, until time.Time) (*CombatLogRecord, error) {
+	clr, err := ReadCombatLogRecord(character, logfile, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	clr.CombatLogLines = linesUntil(clr.CombatLogLines, until)
+
+	first, ok := readSessionHeader(logfile.Filename)
+	if !ok {
+		return clr, nil
+	}
+
+	for _, session := range r.laterSessions(first, until) {
+		next, errr := ReadCombatLogRecord(character, session.CombatLogFile, session.headerLength)
+		if errr != nil {
+			return clr, errr
+		}
+
+		clr.CombatLogLines = append(clr.CombatLogLines, linesUntil(next.GetCombatLogLines(), until)...)
+	}
+
+	SortLines(clr.CombatLogLines)
+
+	return clr, nil
+}
+
+// linesUntil returns lines logged until given time, lines without timestamp follow line preceding them.
+func linesUntil(lines []string, until time.Time) []string {
+	kept := make([]string, 0, len(lines))
+	keep := true
+
+	for _, line := range lines {
+		if ts := lineTimestamp(line); !ts.IsZero() {
+			keep = !ts.After(until)
+		}
+
+		if keep {
+			kept = append(kept, line)
+		}
+	}
+
+	return kept
+}
+
+// SortLines orders gamelog lines by timestamp, lines without timestamp stay after line preceding them.
+func SortLines(lines []string) {
+	type timedLine struct {
+		ts   time.Time
+		line string
+	}
+
+	timed := make([]timedLine, len(lines))
+
+	var last time.Time
+
+	for i, line := range lines {
+		if ts := lineTimestamp(line); !ts.IsZero() {
+			last = ts
+		}
+
+		timed[i] = timedLine{ts: last, line: line}
+	}
+
+	sort.SliceStable(timed, func(i, j int) bool { return timed[i].ts.Before(timed[j].ts) })
+
+	for i := range timed {
+		lines[i] = timed[i].line
+	}
+}
+
+// laterSessions returns session files of same listener as first, started after it and before until, ordered by session start.
+func (r *Reader) laterSessions(first sessionFile, until time.Time) []sessionFile {
+	files, err := r.GetLogFiles(until, until.Sub(first.sessionStarted)+time.Hour)
+	if err != nil {
+		return nil
+	}
+
+	sessions := make([]sessionFile, 0)
+
+	for _, filename := range files {
+		if filepath.Clean(filename) == filepath.Clean(first.Filename) {
+			continue
+		}
+
+		session, ok := readSessionHeader(filename)
+		if !ok || session.character != first.character {
+			continue
+		}
+
+		if session.sessionStarted.After(first.sessionStarted) && !session.sessionStarted.After(until) {
+			sessions = append(sessions, session)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].sessionStarted.Before(sessions[j].sessionStarted) })
+
+	return sessions
+}
+
+// sessionFile is gamelog file with its header parsed.
+type sessionFile struct {
+	CombatLogFile
+	character      string
+	sessionStarted time.Time
+	headerLength   int64
+}
+
+// readSessionHeader reads listener, session start and language out of gamelog banner.
+func readSessionHeader(filename string) (sessionFile, bool) {
+	header, err := ParseHeaderFile(filename)
+	if err != nil {
+		return sessionFile{}, false
+	}
+
+	return sessionFile{
+		CombatLogFile:  CombatLogFile{Filename: filename, LanguageCode: header.LanguageCode},
+		character:      header.Listener,
+		sessionStarted: header.SessionStarted,
+		headerLength:   header.Length,
+	}, true
+}
+
 func genPrefixes(end time.Time, timeWindow time.Duration) []string {
 	begin := end.Add(-1*timeWindow - time.Second)
 
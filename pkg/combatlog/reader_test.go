@@ -1,7 +1,10 @@
 package combatlog
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -138,3 +141,44 @@ func TestReader_MapCharactersToFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestReader_ReadCombatLogRecordUntil(t *testing.T) {
+	dir := t.TempDir()
+	header := func(listener, session string) string {
+		return strings.Replace(replaceSession(tailerHeader, session), "Runner1", listener, 1)
+	}
+
+	first := filepath.Join(dir, "20201022_205944.txt")
+	writeGamelog(t, first, header("Runner1", "2020.10.22 20:59:44")+"[ 2020.10.22 21:00:00 ] (combat) before start\n")
+
+	offset, err := os.Stat(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeGamelog(t, first, "[ 2020.10.22 21:00:57 ] (combat) first session\n[ 2020.10.22 21:02:30 ] (notify) written late\n"+
+		"[ 2020.10.22 21:21:00 ] (combat) first session after run\n continued after run\n")
+	writeGamelog(t, filepath.Join(dir, "20201022_210200.txt"), header("Runner1", "2020.10.22 21:02:00")+
+		"[ 2020.10.22 21:02:01 ] (combat) after relog\n continued\n[ 2020.10.22 21:25:00 ] (combat) after run\n continued after run\n")
+	writeGamelog(t, filepath.Join(dir, "20201022_210100.txt"), header("Runner2", "2020.10.22 21:01:00")+"[ 2020.10.22 21:01:01 ] (combat) other listener\n")
+	writeGamelog(t, filepath.Join(dir, "20201022_213000.txt"), header("Runner1", "2020.10.22 21:30:00")+"[ 2020.10.22 21:30:01 ] (combat) next run\n")
+
+	until := time.Date(2020, 10, 22, 21, 20, 0, 0, time.UTC)
+	logfile := CombatLogFile{Filename: first, LanguageCode: LanguageCode_ENGLISH}
+
+	clr, err := NewReader(dir).ReadCombatLogRecordUntil("Runner1", logfile, offset.Size(), until)
+	if err != nil {
+		t.Fatalf("Reader.ReadCombatLogRecordUntil() error = %v", err)
+	}
+
+	want := []string{
+		"[ 2020.10.22 21:00:57 ] (combat) first session",
+		"[ 2020.10.22 21:02:01 ] (combat) after relog",
+		" continued",
+		"[ 2020.10.22 21:02:30 ] (notify) written late",
+	}
+
+	if !reflect.DeepEqual(clr.GetCombatLogLines(), want) {
+		t.Errorf("Reader.ReadCombatLogRecordUntil() lines = %q, want %q", clr.GetCombatLogLines(), want)
+	}
+}
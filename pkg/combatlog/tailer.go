@@ -0,0 +1,263 @@
+package combatlog
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTailInterval is how often Tailer checks gamelog files for new lines.
+const DefaultTailInterval = time.Second
+
+// Line is single gamelog line read by Tailer.
+type Line struct {
+	Character    string
+	Filename     string
+	LanguageCode LanguageCode
+	Text         string
+	// Timestamp of line, zero when line has none (e.g. continuation of multi-line message).
+	Timestamp time.Time
+}
+
+// Tailer follows gamelog files of characters while they are written, switching to new session file when client rotates log.
+type Tailer struct {
+	reader        *Reader
+	interval      time.Duration
+	rotationCheck time.Duration
+	lastRotation  time.Time
+
+	mu      sync.Mutex
+	files   map[string]*tailedFile
+	pending []Line
+
+	lines    chan Line
+	started  bool // guarded by mu
+	stopOnce sync.Once
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+type tailedFile struct {
+	CombatLogFile
+	offset  int64
+	partial []byte // last line not terminated yet
+}
+
+// NewTailer creates tailer following files of characters from given offsets, missing offset means beginning of file.
+// Reader is used to find new session files of tracked characters.
+func NewTailer(reader *Reader, characters map[string]CombatLogFile, offsets map[string]int64, interval time.Duration) *Tailer {
+	t := &Tailer{
+		reader:        reader,
+		interval:      interval,
+		rotationCheck: 10 * interval,
+		lastRotation:  time.Now(),
+		files:         make(map[string]*tailedFile, len(characters)),
+		lines:         make(chan Line, 256),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+
+	for character, logfile := range characters {
+		t.files[character] = &tailedFile{CombatLogFile: logfile, offset: offsets[character]}
+	}
+
+	return t
+}
+
+// Lines returns channel lines are delivered on, it is closed by Stop.
+func (t *Tailer) Lines() <-chan Line {
+	return t.lines
+}
+
+// Start starts following files in background.
+func (t *Tailer) Start() {
+	t.mu.Lock()
+	t.started = true
+	t.mu.Unlock()
+
+	go t.run()
+}
+
+// Stop stops following files and returns lines that were not delivered on Lines channel yet,
+// including lines written since last check and unterminated last line of every file.
+// Stopping stopped tailer does nothing and returns no lines.
+func (t *Tailer) Stop() []Line {
+	var lines []Line
+
+	t.stopOnce.Do(func() {
+		close(t.done)
+
+		t.mu.Lock()
+		started := t.started
+		t.mu.Unlock()
+
+		if started {
+			<-t.stopped
+		}
+
+		close(t.lines)
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		lines = append(t.pending, t.poll(true)...)
+		t.pending = nil
+	})
+
+	return lines
+}
+
+// Poll reads lines written since last call, without delivering them on Lines channel.
+// It is meant for callers driving tailer themselves instead of calling Start.
+func (t *Tailer) Poll() []Line {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.poll(false)
+}
+
+func (t *Tailer) run() {
+	defer close(t.stopped)
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		t.mu.Lock()
+		t.pending = append(t.pending, t.poll(false)...)
+		t.mu.Unlock()
+
+		if !t.deliver() {
+			return
+		}
+
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliver sends pending lines to Lines channel, returns false when tailer was stopped meanwhile.
+func (t *Tailer) deliver() bool {
+	for {
+		t.mu.Lock()
+		if len(t.pending) == 0 {
+			t.mu.Unlock()
+			return true
+		}
+
+		line := t.pending[0]
+		t.mu.Unlock()
+
+		select {
+		case <-t.done:
+			return false
+		case t.lines <- line:
+		}
+
+		t.mu.Lock()
+		t.pending = t.pending[1:]
+		t.mu.Unlock()
+	}
+}
+
+func (t *Tailer) poll(final bool) []Line {
+	var lines []Line
+
+	for character, tf := range t.files {
+		lines = append(lines, t.readFile(character, tf, final)...)
+	}
+
+	// client starts new session file on relog, directory is scanned only every rotationCheck
+	if final || time.Since(t.lastRotation) >= t.rotationCheck {
+		t.lastRotation = time.Now()
+		lines = append(lines, t.rotate(final)...)
+	}
+
+	return lines
+}
+
+// rotate switches characters to their newest session file, reading rest of previous file first.
+func (t *Tailer) rotate(final bool) []Line {
+	files, err := t.reader.GetLogFiles(time.Now(), 24*time.Hour)
+	if err != nil {
+		return nil
+	}
+
+	var lines []Line
+
+	for character, logfile := range t.reader.MapCharactersToFiles(files) {
+		tf, tracked := t.files[character]
+		if !tracked || logfile.Filename == tf.Filename {
+			continue
+		}
+
+		header, errr := ParseHeaderFile(logfile.Filename)
+		if errr != nil {
+			continue
+		}
+
+		lines = append(lines, t.readFile(character, tf, true)...)
+
+		tf = &tailedFile{CombatLogFile: logfile, offset: header.Length}
+		t.files[character] = tf
+
+		lines = append(lines, t.readFile(character, tf, final)...)
+	}
+
+	return lines
+}
+
+// readFile reads complete lines appended to file since last read, final read includes unterminated last line.
+func (t *Tailer) readFile(character string, tf *tailedFile, final bool) []Line {
+	file, err := os.Open(tf.Filename)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	if _, err = file.Seek(tf.offset, io.SeekStart); err != nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil || len(data) == 0 && (!final || len(tf.partial) == 0) {
+		return nil
+	}
+
+	tf.offset += int64(len(data))
+	data = append(tf.partial, data...)
+	tf.partial = nil
+
+	var lines []Line
+
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			if !final {
+				tf.partial = data
+				break
+			}
+
+			i = len(data)
+		}
+
+		text := strings.TrimRight(string(data[:i]), "\r")
+		data = data[min(i+1, len(data)):]
+
+		lines = append(lines, Line{
+			Character:    character,
+			Filename:     tf.Filename,
+			LanguageCode: tf.LanguageCode,
+			Text:         text,
+			Timestamp:    lineTimestamp(text),
+		})
+	}
+
+	return lines
+}
@@ -0,0 +1,92 @@
+package combatlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const tailerHeader = `------------------------------------------------------------
+  Gamelog
+  Listener: Runner1
+  Session Started: %s
+------------------------------------------------------------
+`
+
+func writeGamelog(t *testing.T, filename, content string) {
+	t.Helper()
+
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err = f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTailer(t *testing.T) {
+	dir := t.TempDir()
+	prefix := filepath.Join(dir, time.Now().UTC().Format("20060102"))
+	first, second := prefix+"_100000.txt", prefix+"_110000.txt"
+
+	writeGamelog(t, first, replaceSession(tailerHeader, "2020.10.22 20:59:44"))
+
+	reader := NewReader(dir)
+	characters := reader.MapCharactersToFiles([]string{first})
+	reader.MarkStartOffsets(characters)
+
+	tailer := NewTailer(reader, characters, reader.StartOffsets(), DefaultTailInterval)
+	tailer.rotationCheck = 0
+
+	writeGamelog(t, first, "[ 2020.10.22 21:00:57 ] (combat) first\r\n[ 2020.10.22 21:00:58 ] (notify) second\n[ 2020.10.22 21:00:59 ] (combat) par")
+
+	lines := tailer.Poll()
+	if len(lines) != 2 || lines[0].Text != "[ 2020.10.22 21:00:57 ] (combat) first" || lines[1].Character != "Runner1" {
+		t.Fatalf("Tailer.Poll() = %+v", lines)
+	}
+
+	if want := time.Date(2020, 10, 22, 21, 0, 58, 0, time.UTC); !lines[1].Timestamp.Equal(want) {
+		t.Errorf("Line.Timestamp = %s, want %s", lines[1].Timestamp, want)
+	}
+
+	// client relogs: previous file gets rest of last line, new session file is created
+	writeGamelog(t, first, "tial\n")
+	writeGamelog(t, second, replaceSession(tailerHeader, "2020.10.22 21:01:10")+"[ 2020.10.22 21:01:11 ] (combat) after relog\n")
+
+	lines = tailer.Poll()
+	if len(lines) != 2 || lines[0].Text != "[ 2020.10.22 21:00:59 ] (combat) partial" || lines[1].Filename != second || lines[1].Text != "[ 2020.10.22 21:01:11 ] (combat) after relog" {
+		t.Fatalf("Tailer.Poll() after rotation = %+v", lines)
+	}
+
+	tailer.Start()
+	writeGamelog(t, second, "[ 2020.10.22 21:01:12 ] (combat) last")
+
+	remaining := tailer.Stop()
+	for line := range tailer.Lines() {
+		remaining = append(remaining, line)
+	}
+
+	if len(remaining) != 1 || remaining[0].Text != "[ 2020.10.22 21:01:12 ] (combat) last" {
+		t.Errorf("lines after Tailer.Stop() = %+v", remaining)
+	}
+}
+
+func TestTailer_StopTwice(t *testing.T) {
+	tailer := NewTailer(NewReader(t.TempDir()), nil, nil, time.Millisecond)
+	tailer.Start()
+
+	tailer.Stop()
+
+	if lines := tailer.Stop(); lines != nil {
+		t.Errorf("second Tailer.Stop() = %+v", lines)
+	}
+}
+
+func replaceSession(header, session string) string {
+	return fmt.Sprintf(header, session)
+}
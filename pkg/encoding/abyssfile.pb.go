@@ -89,7 +89,19 @@ type AbyssRecording struct {
 	AbyssWheather           string                       `protobuf:"bytes,9,opt,name=abyss_wheather,json=abyssWheather,proto3" json:"abyss_wheather,omitempty"`
 	AbyssTier               int32                        `protobuf:"varint,10,opt,name=abyss_tier,json=abyssTier,proto3" json:"abyss_tier,omitempty"`
 	Fittings                map[string]*Fit              `protobuf:"bytes,11,rep,name=fittings,proto3" json:"fittings,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	RecorderVersion         string                       `protobuf:"bytes,99,opt,name=recorder_version,json=recorderVersion,proto3" json:"recorder_version,omitempty"`
+	// overview_frames is compact alternative to overview GIF, when set overview might be empty.
+	OverviewFrames *OverviewFrames `protobuf:"bytes,12,opt,name=overview_frames,json=overviewFrames,proto3" json:"overview_frames,omitempty"`
+	// frame_timestamps is wall-clock capture time of every overview frame in unix milliseconds.
+	FrameTimestamps []int64 `protobuf:"varint,13,rep,packed,name=frame_timestamps,json=frameTimestamps,proto3" json:"frame_timestamps,omitempty"`
+	// started_at and stopped_at are wall-clock times of recording start and stop in unix milliseconds.
+	StartedAt int64 `protobuf:"varint,14,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	StoppedAt int64 `protobuf:"varint,15,opt,name=stopped_at,json=stoppedAt,proto3" json:"stopped_at,omitempty"`
+	// detected_filament is name of filament consumed between first two loot records, abyss type was detected from it.
+	// Abyss type fields are filled from detected filament unless manual override was chosen.
+	DetectedFilament string `protobuf:"bytes,16,opt,name=detected_filament,json=detectedFilament,proto3" json:"detected_filament,omitempty"`
+	// ignored_clipboard is count of clipboard changes during recording not recorded as loot, because they were not inventory copies.
+	IgnoredClipboard int32  `protobuf:"varint,17,opt,name=ignored_clipboard,json=ignoredClipboard,proto3" json:"ignored_clipboard,omitempty"`
+	RecorderVersion  string `protobuf:"bytes,99,opt,name=recorder_version,json=recorderVersion,proto3" json:"recorder_version,omitempty"`
 }
 
 func (x *AbyssRecording) Reset() {
@@ -201,6 +213,48 @@ func (x *AbyssRecording) GetFittings() map[string]*Fit {
 	return nil
 }
 
+func (x *AbyssRecording) GetOverviewFrames() *OverviewFrames {
+	if x != nil {
+		return x.OverviewFrames
+	}
+	return nil
+}
+
+func (x *AbyssRecording) GetFrameTimestamps() []int64 {
+	if x != nil {
+		return x.FrameTimestamps
+	}
+	return nil
+}
+
+func (x *AbyssRecording) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *AbyssRecording) GetStoppedAt() int64 {
+	if x != nil {
+		return x.StoppedAt
+	}
+	return 0
+}
+
+func (x *AbyssRecording) GetDetectedFilament() string {
+	if x != nil {
+		return x.DetectedFilament
+	}
+	return ""
+}
+
+func (x *AbyssRecording) GetIgnoredClipboard() int32 {
+	if x != nil {
+		return x.IgnoredClipboard
+	}
+	return 0
+}
+
 func (x *AbyssRecording) GetRecorderVersion() string {
 	if x != nil {
 		return x.RecorderVersion
@@ -215,6 +269,11 @@ type LootRecord struct {
 
 	Frame int32  `protobuf:"varint,1,opt,name=frame,proto3" json:"frame,omitempty"`
 	Loot  string `protobuf:"bytes,2,opt,name=loot,proto3" json:"loot,omitempty"`
+	// timestamp is wall-clock time of loot capture in unix milliseconds.
+	Timestamp int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// character is recorded character cargo was copied from, resolved by loot record discriminator quantity.
+	// Empty when it could not be resolved.
+	Character string `protobuf:"bytes,4,opt,name=character,proto3" json:"character,omitempty"`
 }
 
 func (x *LootRecord) Reset() {
@@ -263,6 +322,20 @@ func (x *LootRecord) GetLoot() string {
 	return ""
 }
 
+func (x *LootRecord) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *LootRecord) GetCharacter() string {
+	if x != nil {
+		return x.Character
+	}
+	return ""
+}
+
 type Fit struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -371,7 +444,8 @@ var File_abyssfile_proto protoreflect.FileDescriptor
 var file_abyssfile_proto_rawDesc = []byte{
 	0x0a, 0x0f, 0x61, 0x62, 0x79, 0x73, 0x73, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x1a, 0x0f, 0x63, 0x6f, 0x6d,
-	0x62, 0x61, 0x74, 0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf3, 0x05, 0x0a,
+	0x62, 0x61, 0x74, 0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0e, 0x6f, 0x76,
+	0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf9, 0x07, 0x0a,
 	0x0e, 0x41, 0x62, 0x79, 0x73, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12,
 	0x1a, 0x0a, 0x08, 0x6f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0c, 0x52, 0x08, 0x6f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x12, 0x28, 0x0a, 0x04, 0x6c,
@@ -407,40 +481,60 @@ var file_abyssfile_proto_rawDesc = []byte{
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x62, 0x79, 0x73, 0x73, 0x52, 0x65,
 	0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
 	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x66, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12,
-	0x29, 0x0a, 0x10, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x18, 0x63, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x4a, 0x0a, 0x0d, 0x46, 0x69,
-	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x23, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4a, 0x0a, 0x0d, 0x41, 0x62, 0x79, 0x73, 0x73, 0x53,
-	0x68, 0x69, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
-	0x57, 0x4e, 0x5f, 0x53, 0x48, 0x49, 0x50, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52, 0x55,
-	0x49, 0x53, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x45, 0x53, 0x54, 0x52, 0x4f,
-	0x59, 0x45, 0x52, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x52, 0x49, 0x47, 0x41, 0x54, 0x45,
-	0x10, 0x03, 0x22, 0x36, 0x0a, 0x0a, 0x4c, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x12, 0x14, 0x0a, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x6f, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x6f, 0x6f, 0x74, 0x22, 0xe6, 0x01, 0x0a, 0x03, 0x46,
-	0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x09, 0x66, 0x6f,
-	0x72, 0x65, 0x69, 0x67, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x09, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x49, 0x44, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a,
-	0x0b, 0x66, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x10, 0x0a, 0x03, 0x45, 0x46, 0x54, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x45, 0x46,
-	0x54, 0x12, 0x10, 0x0a, 0x03, 0x46, 0x46, 0x48, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x46, 0x46, 0x48, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68, 0x69,
-	0x70, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68, 0x69,
-	0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x68, 0x69, 0x70, 0x54, 0x79, 0x70,
-	0x65, 0x49, 0x44, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x68, 0x69, 0x70, 0x54,
-	0x79, 0x70, 0x65, 0x49, 0x44, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67,
-	0x6e, 0x49, 0x44, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x73, 0x68, 0x69, 0x76, 0x61, 0x73, 0x2f, 0x61, 0x62, 0x79, 0x73, 0x73, 0x2d, 0x62,
-	0x6c, 0x61, 0x63, 0x6b, 0x62, 0x6f, 0x78, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x65, 0x6e, 0x63, 0x6f,
-	0x64, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x41, 0x0a, 0x0f, 0x6f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x73, 0x52, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0f, 0x66, 0x72,
+	0x61, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x64,
+	0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x61, 0x6d, 0x65, 0x6e, 0x74,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x46, 0x69, 0x6c, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x67, 0x6e, 0x6f,
+	0x72, 0x65, 0x64, 0x5f, 0x63, 0x6c, 0x69, 0x70, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x10, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x64, 0x43, 0x6c, 0x69, 0x70,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x63, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x1a, 0x4a, 0x0a, 0x0d, 0x46, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69,
+	0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4a, 0x0a, 0x0d,
+	0x41, 0x62, 0x79, 0x73, 0x73, 0x53, 0x68, 0x69, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a,
+	0x0c, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x53, 0x48, 0x49, 0x50, 0x10, 0x00, 0x12,
+	0x0b, 0x0a, 0x07, 0x43, 0x52, 0x55, 0x49, 0x53, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09,
+	0x44, 0x45, 0x53, 0x54, 0x52, 0x4f, 0x59, 0x45, 0x52, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x46,
+	0x52, 0x49, 0x47, 0x41, 0x54, 0x45, 0x10, 0x03, 0x22, 0x72, 0x0a, 0x0a, 0x4c, 0x6f, 0x6f, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6c, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x6f, 0x6f, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1c,
+	0x0a, 0x09, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x22, 0xe6, 0x01, 0x0a,
+	0x03, 0x46, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x09,
+	0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x09, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x49, 0x44, 0x88, 0x01, 0x01, 0x12,
+	0x20, 0x0a, 0x0b, 0x66, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x45, 0x46, 0x54, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x45, 0x46, 0x54, 0x12, 0x10, 0x0a, 0x03, 0x46, 0x46, 0x48, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x46, 0x46, 0x48, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x68, 0x69, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x68, 0x69, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x68, 0x69, 0x70, 0x54,
+	0x79, 0x70, 0x65, 0x49, 0x44, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x68, 0x69,
+	0x70, 0x54, 0x79, 0x70, 0x65, 0x49, 0x44, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x66, 0x6f, 0x72, 0x65,
+	0x69, 0x67, 0x6e, 0x49, 0x44, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x68, 0x69, 0x76, 0x61, 0x73, 0x2f, 0x61, 0x62, 0x79, 0x73, 0x73,
+	0x2d, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x62, 0x6f, 0x78, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x65, 0x6e,
+	0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -464,18 +558,20 @@ var file_abyssfile_proto_goTypes = []interface{}{
 	(*Fit)(nil),                       // 3: protobuf.Fit
 	nil,                               // 4: protobuf.AbyssRecording.FittingsEntry
 	(*combatlog.CombatLogRecord)(nil), // 5: combatlog.CombatLogRecord
+	(*OverviewFrames)(nil),            // 6: protobuf.OverviewFrames
 }
 var file_abyssfile_proto_depIdxs = []int32{
 	2, // 0: protobuf.AbyssRecording.loot:type_name -> protobuf.LootRecord
 	5, // 1: protobuf.AbyssRecording.combat_log:type_name -> combatlog.CombatLogRecord
 	0, // 2: protobuf.AbyssRecording.abyss_ship_type:type_name -> protobuf.AbyssRecording.AbyssShipType
 	4, // 3: protobuf.AbyssRecording.fittings:type_name -> protobuf.AbyssRecording.FittingsEntry
-	3, // 4: protobuf.AbyssRecording.FittingsEntry.value:type_name -> protobuf.Fit
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	6, // 4: protobuf.AbyssRecording.overview_frames:type_name -> protobuf.OverviewFrames
+	3, // 5: protobuf.AbyssRecording.FittingsEntry.value:type_name -> protobuf.Fit
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_abyssfile_proto_init() }
@@ -483,6 +579,7 @@ func file_abyssfile_proto_init() {
 	if File_abyssfile_proto != nil {
 		return
 	}
+	file_overview_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_abyssfile_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AbyssRecording); i {
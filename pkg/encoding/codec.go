@@ -0,0 +1,169 @@
+package encoding
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compressor implements compression codec of .abyss container.
+type Compressor interface {
+	// NewWriter returns writer compressing into w, level is codec specific, DefaultLevel selects codec default.
+	NewWriter(w io.Writer, level int) (io.WriteCloser, error)
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// DefaultLevel selects default compression level of codec.
+const DefaultLevel = -1
+
+type registeredCodec struct {
+	name       string
+	compressor Compressor
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[Codec]registeredCodec{
+		CodecGzip:    {"gzip", gzipCompressor{}},
+		CodecDeflate: {"deflate", deflateCompressor{}},
+		CodecZstd:    {"zstd", zstdCompressor{}},
+	}
+)
+
+// RegisterCodec makes codec available to Encode and Decode, use it to plug in codecs outside of standard library (zstd, brotli...).
+func RegisterCodec(c Codec, name string, compressor Compressor) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+
+	codecs[c] = registeredCodec{name: name, compressor: compressor}
+}
+
+// Codecs returns all registered codecs.
+func Codecs() []Codec {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+
+	result := make([]Codec, 0, len(codecs))
+	for c := range codecs {
+		result = append(result, c)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	return result
+}
+
+// ParseCodec returns codec registered with given name.
+func ParseCodec(name string) (Codec, error) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+
+	for c, rc := range codecs {
+		if rc.name == name {
+			return c, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrUnsupportedCodec, name)
+}
+
+func compressor(c Codec) (Compressor, error) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+
+	rc, ok := codecs[c]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCodec, c)
+	}
+
+	return rc.compressor, nil
+}
+
+// EncodeOption changes how recording is encoded.
+type EncodeOption func(*encodeOptions)
+
+type encodeOptions struct {
+	codec    Codec
+	level    int
+	noHeader bool
+}
+
+// WithCodec selects compression codec.
+func WithCodec(c Codec) EncodeOption {
+	return func(o *encodeOptions) {
+		o.codec = c
+	}
+}
+
+// WithLevel selects compression level of codec, e.g. gzip.BestCompression.
+func WithLevel(level int) EncodeOption {
+	return func(o *encodeOptions) {
+		o.level = level
+	}
+}
+
+// WithoutHeader writes legacy headerless gzip recording, as read by recorders and services predating container header.
+func WithoutHeader() EncodeOption {
+	return func(o *encodeOptions) {
+		o.noHeader = true
+	}
+}
+
+type gzipCompressor struct{}
+
+func (gzipCompressor) NewWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	if level == DefaultLevel {
+		level = gzip.DefaultCompression
+	}
+
+	return gzip.NewWriterLevel(w, level)
+}
+
+func (gzipCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// deflateCompressor stores raw deflate stream, container header already carries size and checksum.
+type deflateCompressor struct{}
+
+func (deflateCompressor) NewWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	if level == DefaultLevel {
+		level = flate.DefaultCompression
+	}
+
+	return flate.NewWriter(w, level)
+}
+
+func (deflateCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return flate.NewReader(r), nil
+}
+
+// zstdCompressor compresses faster and smaller than gzip, level is zstd level (1-22).
+type zstdCompressor struct{}
+
+func (zstdCompressor) NewWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	encoderLevel := zstd.SpeedDefault
+	if level != DefaultLevel {
+		if level < 1 || level > 22 {
+			return nil, fmt.Errorf("zstd: invalid compression level: %d", level)
+		}
+
+		encoderLevel = zstd.EncoderLevelFromZstd(level)
+	}
+
+	return zstd.NewWriter(w, zstd.WithEncoderLevel(encoderLevel))
+}
+
+func (zstdCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+
+	return d.IOReadCloser(), nil
+}
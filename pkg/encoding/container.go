@@ -0,0 +1,118 @@
+package encoding
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ContainerVersion is version of .abyss container written by Encode.
+const ContainerVersion = 1
+
+const (
+	containerMagic = "ABYSSREC"
+	headerSize     = len(containerMagic) + 1 + 1 + 8 + 4
+	gzipMagic      = "\x1f\x8b"
+)
+
+// Codec identifies compression of recording inside container.
+type Codec uint8
+
+// Codecs available out of the box, see RegisterCodec for adding more.
+const (
+	CodecGzip    Codec = 1
+	CodecDeflate Codec = 2
+	CodecZstd    Codec = 3
+)
+
+func (c Codec) String() string {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+
+	if rc, ok := codecs[c]; ok {
+		return rc.name
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(c))
+}
+
+var (
+	// ErrNotAbyssFile returned when file is neither .abyss container nor legacy headerless recording.
+	ErrNotAbyssFile = errors.New("not an abyss recording")
+	// ErrUnsupportedVersion returned for containers written by newer version of recorder.
+	ErrUnsupportedVersion = errors.New("unsupported abyss container version")
+	// ErrUnsupportedCodec returned when container uses unknown compression.
+	ErrUnsupportedCodec = errors.New("unsupported abyss container codec")
+	// ErrCorrupted returned when recording doesn't match size or checksum stored in header.
+	ErrCorrupted = errors.New("abyss recording is corrupted")
+)
+
+// Header of .abyss container.
+type Header struct {
+	// Version is container version, 0 for legacy headerless files.
+	Version uint8
+	Codec   Codec
+	// Size is length of uncompressed protobuf message.
+	Size uint64
+	// Checksum is CRC32 (IEEE) of uncompressed protobuf message.
+	Checksum uint32
+}
+
+// Legacy returns true for recordings written before container header was introduced.
+func (h *Header) Legacy() bool {
+	return h.Version == 0
+}
+
+// ReadHeader reads container header, legacy recordings get header with Version 0 and no size or checksum.
+func ReadHeader(r io.Reader) (*Header, error) {
+	return readHeader(bufio.NewReader(r))
+}
+
+func readHeader(br *bufio.Reader) (*Header, error) {
+	magic, err := br.Peek(len(containerMagic))
+	if err != nil && len(magic) < len(gzipMagic) {
+		return nil, ErrNotAbyssFile
+	}
+
+	if string(magic) != containerMagic {
+		if string(magic[:len(gzipMagic)]) == gzipMagic {
+			return &Header{Codec: CodecGzip}, nil
+		}
+
+		return nil, ErrNotAbyssFile
+	}
+
+	buf := make([]byte, headerSize)
+	if _, err = io.ReadFull(br, buf); err != nil {
+		return nil, fmt.Errorf("%w: incomplete header", ErrCorrupted)
+	}
+
+	buf = buf[len(containerMagic):]
+
+	h := &Header{
+		Version:  buf[0],
+		Codec:    Codec(buf[1]),
+		Size:     binary.LittleEndian.Uint64(buf[2:]),
+		Checksum: binary.LittleEndian.Uint32(buf[10:]),
+	}
+
+	if h.Version > ContainerVersion {
+		return h, fmt.Errorf("%w: %d", ErrUnsupportedVersion, h.Version)
+	}
+
+	return h, nil
+}
+
+func writeHeader(w io.Writer, h *Header) error {
+	buf := make([]byte, 0, headerSize)
+	buf = append(buf, containerMagic...)
+	buf = append(buf, h.Version, byte(h.Codec))
+	buf = binary.LittleEndian.AppendUint64(buf, h.Size)
+	buf = binary.LittleEndian.AppendUint32(buf, h.Checksum)
+
+	_, err := w.Write(buf)
+
+	return err
+}
@@ -1,46 +1,102 @@
 package encoding
 
-//go:generate protoc -I ../../protobuf --go_opt=paths=source_relative --go_out=. abyssfile.proto
+//go:generate protoc -I ../../protobuf --go_opt=paths=source_relative --go_out=. abyssfile.proto overview.proto
 
 import (
+	"bufio"
 	"bytes"
-	"compress/gzip"
+	"fmt"
+	"hash/crc32"
 	"io"
 	"io/ioutil"
 
 	"google.golang.org/protobuf/proto"
 )
 
-// Encode AbyssRecording into io.WriteCloser
-func (rf *AbyssRecording) Encode(w io.Writer) error {
+// Encode AbyssRecording into io.WriteCloser, gzip with default level is used unless options say otherwise
+func (rf *AbyssRecording) Encode(w io.Writer, opts ...EncodeOption) error {
+	options := encodeOptions{codec: CodecGzip, level: DefaultLevel}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.noHeader && options.codec != CodecGzip {
+		return fmt.Errorf("%w: %s recording without header", ErrUnsupportedCodec, options.codec)
+	}
+
+	comp, err := compressor(options.codec)
+	if err != nil {
+		return err
+	}
+
 	data, err := proto.Marshal(rf)
 	if err != nil {
 		return err
 	}
 
-	gw := gzip.NewWriter(w)
-	defer gw.Close()
+	header := &Header{
+		Version:  ContainerVersion,
+		Codec:    options.codec,
+		Size:     uint64(len(data)),
+		Checksum: crc32.ChecksumIEEE(data),
+	}
 
-	_, err = io.Copy(gw, bytes.NewReader(data))
+	if !options.noHeader {
+		if err = writeHeader(w, header); err != nil {
+			return err
+		}
+	}
 
-	return err
+	cw, err := comp.NewWriter(w, options.level)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(cw, bytes.NewReader(data)); err != nil {
+		cw.Close()
+		return err
+	}
+
+	return cw.Close()
 }
 
-// Decode abyss file back into AbyssRecording struct
+// Decode abyss file back into AbyssRecording struct, legacy headerless files are supported
 func Decode(r io.Reader) (*AbyssRecording, error) {
-	gr, err := gzip.NewReader(r)
+	br := bufio.NewReader(r)
+
+	header, err := readHeader(br)
+	if err != nil {
+		return nil, err
+	}
+
+	comp, err := compressor(header.Codec)
 	if err != nil {
 		return nil, err
 	}
-	defer gr.Close()
+
+	cr, err := comp.NewReader(br)
+	if err != nil {
+		return nil, err
+	}
+	defer cr.Close()
 
 	result := &AbyssRecording{}
 
-	data, err := ioutil.ReadAll(gr)
+	data, err := ioutil.ReadAll(cr)
 	if err != nil {
 		return result, err
 	}
 
+	if !header.Legacy() {
+		if uint64(len(data)) != header.Size {
+			return result, fmt.Errorf("%w: size %d, expected %d", ErrCorrupted, len(data), header.Size)
+		}
+
+		if crc32.ChecksumIEEE(data) != header.Checksum {
+			return result, fmt.Errorf("%w: checksum mismatch", ErrCorrupted)
+		}
+	}
+
 	err = proto.Unmarshal(data, result)
 
 	return result, err
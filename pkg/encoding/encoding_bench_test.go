@@ -0,0 +1,133 @@
+package encoding
+
+import (
+	"bytes"
+	"compress/gzip"
+	"image"
+	"image/color"
+	"image/gif"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shivas/abyss-blackbox/pkg/combatlog"
+)
+
+// benchmarkRecording builds recording resembling real run: 20 minutes of overview frames where few rows change every second and real combat log.
+func benchmarkRecording(b *testing.B) *AbyssRecording {
+	b.Helper()
+
+	const (
+		frames  = 1200
+		width   = 255
+		height  = 400
+		rowSize = 16
+	)
+
+	rnd := rand.New(rand.NewSource(1))
+	palette := []color.Color{color.White, color.Black}
+	anim := gif.GIF{LoopCount: -1}
+
+	frame := image.NewPaletted(image.Rect(0, 0, width, height), palette)
+
+	for i := 0; i < frames; i++ {
+		// overview rows (names, distances) change few at a time
+		for changes := rnd.Intn(4); changes > 0; changes-- {
+			row := rnd.Intn(height / rowSize)
+			for y := row * rowSize; y < (row+1)*rowSize-4; y++ {
+				for x := 20; x < width; x++ {
+					frame.Pix[y*frame.Stride+x] = uint8(rnd.Intn(8) / 7)
+				}
+			}
+		}
+
+		next := image.NewPaletted(frame.Rect, palette)
+		copy(next.Pix, frame.Pix)
+		anim.Image = append(anim.Image, next)
+		anim.Delay = append(anim.Delay, FrameDelay)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, &anim); err != nil {
+		b.Fatal(err)
+	}
+
+	logData, err := os.ReadFile(filepath.Join("..", "combatlog", "testdata", "20201022_205944.txt"))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return &AbyssRecording{
+		Overview: buf.Bytes(),
+		Loot: []*LootRecord{
+			{Frame: 0, Loot: "Calm Dark Filament\t1\nQuafe\t1\n"},
+			{Frame: 1150, Loot: "Quafe\t1\nTriglavian Survey Database\t12\nIntact Armor Plates\t7\n"},
+		},
+		CombatLog: []*combatlog.CombatLogRecord{{
+			CharacterName:  "Runner1",
+			LanguageCode:   combatlog.LanguageCode_ENGLISH,
+			CombatLogLines: strings.Split(string(logData), "\n"),
+		}},
+		WeatherStrength: 50,
+		RecorderVersion: "benchmark",
+	}
+}
+
+var benchmarkCodecs = []struct {
+	name string
+	opts []EncodeOption
+}{
+	{"gzip_default", nil},
+	{"gzip_best_speed", []EncodeOption{WithLevel(gzip.BestSpeed)}},
+	{"gzip_best_compression", []EncodeOption{WithLevel(gzip.BestCompression)}},
+	{"gzip_huffman_only", []EncodeOption{WithLevel(gzip.HuffmanOnly)}},
+	{"deflate_best_compression", []EncodeOption{WithCodec(CodecDeflate), WithLevel(gzip.BestCompression)}},
+	{"zstd", []EncodeOption{WithCodec(CodecZstd)}},
+	{"zstd_best_compression", []EncodeOption{WithCodec(CodecZstd), WithLevel(19)}},
+}
+
+func BenchmarkEncode(b *testing.B) {
+	rec := benchmarkRecording(b)
+
+	for _, bc := range benchmarkCodecs {
+		b.Run(bc.name, func(b *testing.B) {
+			var buf bytes.Buffer
+
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+
+				if err := rec.Encode(&buf, bc.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ReportMetric(float64(buf.Len()), "bytes")
+		})
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	rec := benchmarkRecording(b)
+
+	for _, bc := range benchmarkCodecs {
+		b.Run(bc.name, func(b *testing.B) {
+			var buf bytes.Buffer
+			if err := rec.Encode(&buf, bc.opts...); err != nil {
+				b.Fatal(err)
+			}
+
+			encoded := buf.Bytes()
+
+			b.SetBytes(int64(len(encoded)))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := Decode(bytes.NewReader(encoded)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -2,10 +2,14 @@ package encoding
 
 import (
 	"bytes"
+	"compress/gzip"
+	"errors"
+	"hash/crc32"
 	"io"
 	"testing"
 
 	"github.com/shivas/abyss-blackbox/pkg/combatlog"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestAbyssRecording_Encode(t *testing.T) {
@@ -51,3 +55,115 @@ func TestAbyssRecording_Encode(t *testing.T) {
 		})
 	}
 }
+
+func TestDecode(t *testing.T) {
+	record := &AbyssRecording{Overview: []byte{1, 2, 3}, RecorderVersion: "test"}
+
+	var encoded bytes.Buffer
+	if err := record.Encode(&encoded); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := proto.Marshal(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var legacy bytes.Buffer
+
+	gw := gzip.NewWriter(&legacy)
+	_, _ = gw.Write(data)
+	gw.Close()
+
+	var encodedLegacy bytes.Buffer
+	if err = record.Encode(&encodedLegacy, WithoutHeader()); err != nil {
+		t.Fatal(err)
+	}
+
+	withHeader := func(modify func(h *Header)) []byte {
+		h := &Header{Version: ContainerVersion, Codec: CodecGzip, Size: uint64(len(data)), Checksum: crc32.ChecksumIEEE(data)}
+		modify(h)
+
+		var buf bytes.Buffer
+		_ = writeHeader(&buf, h)
+		buf.Write(legacy.Bytes())
+
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name    string
+		input   []byte
+		wantErr error
+	}{
+		{"container", encoded.Bytes(), nil},
+		{"legacy headerless", legacy.Bytes(), nil},
+		{"encoded legacy", encodedLegacy.Bytes(), nil},
+		{"not abyss file", []byte("hello world"), ErrNotAbyssFile},
+		{"empty", nil, ErrNotAbyssFile},
+		{"incomplete header", encoded.Bytes()[:12], ErrCorrupted},
+		{"checksum mismatch", withHeader(func(h *Header) { h.Checksum++ }), ErrCorrupted},
+		{"size mismatch", withHeader(func(h *Header) { h.Size++ }), ErrCorrupted},
+		{"newer version", withHeader(func(h *Header) { h.Version = ContainerVersion + 1 }), ErrUnsupportedVersion},
+		{"unknown codec", withHeader(func(h *Header) { h.Codec = 200 }), ErrUnsupportedCodec},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(bytes.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && got.GetRecorderVersion() != "test" {
+				t.Errorf("Decode() = %v", got)
+			}
+		})
+	}
+}
+
+func TestEncodeCodecs(t *testing.T) {
+	record := &AbyssRecording{Overview: bytes.Repeat([]byte{1, 2, 3}, 100), RecorderVersion: "test"}
+
+	tests := []struct {
+		name    string
+		opts    []EncodeOption
+		wantErr bool
+	}{
+		{"default", nil, false},
+		{"gzip best speed", []EncodeOption{WithCodec(CodecGzip), WithLevel(gzip.BestSpeed)}, false},
+		{"gzip best compression", []EncodeOption{WithLevel(gzip.BestCompression)}, false},
+		{"deflate", []EncodeOption{WithCodec(CodecDeflate)}, false},
+		{"zstd", []EncodeOption{WithCodec(CodecZstd)}, false},
+		{"zstd best compression", []EncodeOption{WithCodec(CodecZstd), WithLevel(19)}, false},
+		{"zstd invalid level", []EncodeOption{WithCodec(CodecZstd), WithLevel(42)}, true},
+		{"without header", []EncodeOption{WithoutHeader()}, false},
+		{"deflate without header", []EncodeOption{WithCodec(CodecDeflate), WithoutHeader()}, true},
+		{"invalid level", []EncodeOption{WithLevel(42)}, true},
+		{"unknown codec", []EncodeOption{WithCodec(200)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := record.Encode(&buf, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AbyssRecording.Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got, err := Decode(&buf)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			if !proto.Equal(got, record) {
+				t.Errorf("Decode() = %v, want %v", got, record)
+			}
+		})
+	}
+}
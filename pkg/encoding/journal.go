@@ -0,0 +1,348 @@
+package encoding
+
+//go:generate protoc -I ../../protobuf --go_opt=paths=source_relative --go_out=. journal.proto
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/combatlog"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// JournalExtension is appended to recording filename to get name of its journal.
+const JournalExtension = ".journal"
+
+// FrameDelay is GIF delay (in 100ths of second) recorded for every overview frame.
+const FrameDelay = 10
+
+const (
+	journalMagic   = "ABYSSJRNL\x01"
+	maxJournalSize = 64 << 20 // single entry, combat log records can get big
+)
+
+// ErrNotJournal returned when file doesn't start with journal magic bytes.
+var ErrNotJournal = errors.New("not an abyss recording journal")
+
+// JournalWriter appends recording data to journal file as it arrives, so recording survives crash of recorder.
+type JournalWriter struct {
+	mu   sync.Mutex
+	file *os.File
+	buf  []byte
+}
+
+// CreateJournal creates journal file and writes initial recording metadata into it.
+func CreateJournal(filename string, metadata *AbyssRecording) (*JournalWriter, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &JournalWriter{file: f}
+
+	if _, err = f.WriteString(journalMagic); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if err = j.AppendMetadata(metadata); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// Filename returns path of journal file.
+func (j *JournalWriter) Filename() string {
+	return j.file.Name()
+}
+
+// AppendMetadata appends metadata, non-empty fields override earlier values during finalization.
+func (j *JournalWriter) AppendMetadata(metadata *AbyssRecording) error {
+	return j.append(&JournalEntry{Entry: &JournalEntry_Metadata{Metadata: metadata}}, true)
+}
+
+// AppendFrame appends single overview frame captured at given time.
+func (j *JournalWriter) AppendFrame(frame *image.Paletted, capturedAt time.Time) error {
+	var buf bytes.Buffer
+
+	if err := gif.Encode(&buf, frame, nil); err != nil {
+		return err
+	}
+
+	entry := &JournalEntry_CapturedFrame{CapturedFrame: &CapturedFrame{Image: buf.Bytes(), CapturedAt: capturedAt.UnixMilli()}}
+
+	return j.append(&JournalEntry{Entry: entry}, false)
+}
+
+// AppendLoot appends loot record.
+func (j *JournalWriter) AppendLoot(lr *LootRecord) error {
+	return j.append(&JournalEntry{Entry: &JournalEntry_Loot{Loot: lr}}, true)
+}
+
+// AppendCombatLog appends (part of) combat log of character.
+func (j *JournalWriter) AppendCombatLog(record *combatlog.CombatLogRecord) error {
+	return j.append(&JournalEntry{Entry: &JournalEntry_CombatLog{CombatLog: record}}, true)
+}
+
+// AppendCombatLogOffset records where combat log of character started, so it can be read again on recovery.
+func (j *JournalWriter) AppendCombatLogOffset(offset *CombatLogOffset) error {
+	return j.append(&JournalEntry{Entry: &JournalEntry_CombatLogOffset{CombatLogOffset: offset}}, true)
+}
+
+// Close closes journal file, leaving it on disk.
+func (j *JournalWriter) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.file.Close()
+}
+
+func (j *JournalWriter) append(entry *JournalEntry, durable bool) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	data, err := proto.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// size and entry written with single call, so crash leaves at most one partial entry at the end
+	j.buf = protowire.AppendVarint(j.buf[:0], uint64(len(data)))
+	j.buf = append(j.buf, data...)
+
+	if _, err = j.file.Write(j.buf); err != nil {
+		return err
+	}
+
+	if durable {
+		return j.file.Sync()
+	}
+
+	return nil
+}
+
+// Journal is content read back from journal file.
+type Journal struct {
+	Recording *AbyssRecording
+	Frames    [][]byte
+	// FrameTimestamps holds capture time of every frame in unix milliseconds, 0 when unknown.
+	FrameTimestamps  []int64
+	CombatLogOffsets []*CombatLogOffset
+	// Truncated is set when journal ends with incomplete entry (recorder crashed while writing).
+	Truncated bool
+}
+
+// ReadJournalFile reads journal from file.
+func ReadJournalFile(filename string) (*Journal, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadJournal(f)
+}
+
+// ReadJournal reads all complete entries of journal, incomplete trailing entry is ignored.
+func ReadJournal(r io.Reader) (*Journal, error) {
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(journalMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != journalMagic {
+		return nil, ErrNotJournal
+	}
+
+	j := &Journal{Recording: &AbyssRecording{}}
+	opts := protodelim.UnmarshalOptions{MaxSize: maxJournalSize}
+
+	for {
+		entry := &JournalEntry{}
+
+		err := opts.UnmarshalFrom(br, entry)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			j.Truncated = true
+			break
+		}
+
+		switch e := entry.GetEntry().(type) {
+		case *JournalEntry_Metadata:
+			proto.Merge(j.Recording, e.Metadata)
+		case *JournalEntry_CapturedFrame:
+			j.Frames = append(j.Frames, e.CapturedFrame.GetImage())
+			j.FrameTimestamps = append(j.FrameTimestamps, e.CapturedFrame.GetCapturedAt())
+		case *JournalEntry_Loot:
+			j.Recording.Loot = append(j.Recording.Loot, e.Loot)
+		case *JournalEntry_CombatLog:
+			j.Recording.CombatLog = mergeCombatLog(j.Recording.CombatLog, e.CombatLog)
+		case *JournalEntry_CombatLogOffset:
+			j.CombatLogOffsets = append(j.CombatLogOffsets, e.CombatLogOffset)
+		}
+	}
+
+	return j, nil
+}
+
+// OverviewFormat selects how Finalize stores overview frames.
+type OverviewFormat int
+
+const (
+	// OverviewFormatGIF stores frames as single animated GIF.
+	OverviewFormatGIF OverviewFormat = iota
+	// OverviewFormatFrames stores frames as compact OverviewFrames.
+	OverviewFormatFrames
+)
+
+// Finalize builds AbyssRecording out of journal, encoding frames into overview of requested format.
+func (j *Journal) Finalize(format OverviewFormat) (*AbyssRecording, error) {
+	if len(j.Frames) == 0 {
+		return nil, fmt.Errorf("journal has no frames")
+	}
+
+	anim := gif.GIF{LoopCount: -1}
+	encoder := NewFrameEncoder(DefaultKeyframeInterval)
+	timestamps := j.completeTimestamps()
+
+	for i, frame := range j.Frames {
+		img, err := gif.Decode(bytes.NewReader(frame))
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding frame %d: %w", i, err)
+		}
+
+		paletted, ok := img.(*image.Paletted)
+		if !ok {
+			return nil, fmt.Errorf("frame %d is not paletted image", i)
+		}
+
+		if format == OverviewFormatFrames {
+			offset := time.Duration(i) * FrameInterval
+			if timestamps != nil {
+				offset = time.Duration(timestamps[i]-timestamps[0]) * time.Millisecond
+			}
+
+			if err = encoder.Add(paletted, offset); err != nil {
+				return nil, fmt.Errorf("failed encoding frame %d: %w", i, err)
+			}
+
+			continue
+		}
+
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, frameDelay(timestamps, i))
+	}
+
+	j.Recording.FrameTimestamps = timestamps
+
+	// combat log appended in chunks can span several session files of character
+	for _, clr := range j.Recording.CombatLog {
+		combatlog.SortLines(clr.CombatLogLines)
+		clr.LanguageCode = combatlog.RecordedLanguageCode(combatlog.DetectLanguage(clr.CombatLogLines).Refine(clr.LanguageCode))
+	}
+
+	if format == OverviewFormatFrames {
+		j.Recording.OverviewFrames = encoder.Frames()
+
+		return j.Recording, nil
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, &anim); err != nil {
+		return nil, err
+	}
+
+	j.Recording.Overview = buf.Bytes()
+
+	return j.Recording, nil
+}
+
+// completeTimestamps returns frame timestamps, nil when capture time of any frame is unknown.
+func (j *Journal) completeTimestamps() []int64 {
+	if len(j.FrameTimestamps) != len(j.Frames) {
+		return nil
+	}
+
+	for _, ts := range j.FrameTimestamps {
+		if ts == 0 {
+			return nil
+		}
+	}
+
+	return j.FrameTimestamps
+}
+
+// ReadMissingCombatLogs reads combat logs of characters that never made it into journal from their recorded offsets,
+// including session files started when character relogged during run.
+func (j *Journal) ReadMissingCombatLogs() []error {
+	var errs []error
+
+	for _, offset := range j.CombatLogOffsets {
+		found := false
+
+		for _, clr := range j.Recording.CombatLog {
+			if clr.GetCharacterName() == offset.GetCharacterName() {
+				found = true
+				break
+			}
+		}
+
+		if found {
+			continue
+		}
+
+		logfile := combatlog.CombatLogFile{Filename: offset.GetFilename(), LanguageCode: offset.GetLanguageCode()}
+		reader := combatlog.NewReader(filepath.Dir(offset.GetFilename()))
+
+		clr, err := reader.ReadCombatLogRecordUntil(offset.GetCharacterName(), logfile, offset.GetOffset(), j.lastActivity())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", offset.GetCharacterName(), err))
+			continue
+		}
+
+		j.Recording.CombatLog = append(j.Recording.CombatLog, clr)
+	}
+
+	return errs
+}
+
+// lastActivity returns when recording stopped, or when its last frame was captured if recorder never stopped.
+func (j *Journal) lastActivity() time.Time {
+	if j.Recording.GetStoppedAt() > 0 {
+		return time.UnixMilli(j.Recording.GetStoppedAt())
+	}
+
+	for i := len(j.FrameTimestamps) - 1; i >= 0; i-- {
+		if j.FrameTimestamps[i] > 0 {
+			return time.UnixMilli(j.FrameTimestamps[i])
+		}
+	}
+
+	return time.Now()
+}
+
+// mergeCombatLog appends lines of record to existing record of same character.
+func mergeCombatLog(records []*combatlog.CombatLogRecord, record *combatlog.CombatLogRecord) []*combatlog.CombatLogRecord {
+	for _, existing := range records {
+		if existing.GetCharacterName() == record.GetCharacterName() {
+			existing.CombatLogLines = append(existing.CombatLogLines, record.GetCombatLogLines()...)
+			return records
+		}
+	}
+
+	return append(records, record)
+}
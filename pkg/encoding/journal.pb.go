@@ -0,0 +1,432 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: journal.proto
+
+package encoding
+
+import (
+	combatlog "github.com/shivas/abyss-blackbox/pkg/combatlog"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// JournalEntry is single length delimited record appended to recording journal while recording is running.
+type JournalEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Entry:
+	//	*JournalEntry_Metadata
+	//	*JournalEntry_Loot
+	//	*JournalEntry_CombatLog
+	//	*JournalEntry_CombatLogOffset
+	//	*JournalEntry_CapturedFrame
+	Entry isJournalEntry_Entry `protobuf_oneof:"entry"`
+}
+
+func (x *JournalEntry) Reset() {
+	*x = JournalEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_journal_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JournalEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalEntry) ProtoMessage() {}
+
+func (x *JournalEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_journal_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalEntry.ProtoReflect.Descriptor instead.
+func (*JournalEntry) Descriptor() ([]byte, []int) {
+	return file_journal_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *JournalEntry) GetEntry() isJournalEntry_Entry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (x *JournalEntry) GetMetadata() *AbyssRecording {
+	if x, ok := x.GetEntry().(*JournalEntry_Metadata); ok {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *JournalEntry) GetLoot() *LootRecord {
+	if x, ok := x.GetEntry().(*JournalEntry_Loot); ok {
+		return x.Loot
+	}
+	return nil
+}
+
+func (x *JournalEntry) GetCombatLog() *combatlog.CombatLogRecord {
+	if x, ok := x.GetEntry().(*JournalEntry_CombatLog); ok {
+		return x.CombatLog
+	}
+	return nil
+}
+
+func (x *JournalEntry) GetCombatLogOffset() *CombatLogOffset {
+	if x, ok := x.GetEntry().(*JournalEntry_CombatLogOffset); ok {
+		return x.CombatLogOffset
+	}
+	return nil
+}
+
+func (x *JournalEntry) GetCapturedFrame() *CapturedFrame {
+	if x, ok := x.GetEntry().(*JournalEntry_CapturedFrame); ok {
+		return x.CapturedFrame
+	}
+	return nil
+}
+
+type isJournalEntry_Entry interface {
+	isJournalEntry_Entry()
+}
+
+type JournalEntry_Metadata struct {
+	// metadata is merged into final recording, later entries override earlier ones.
+	Metadata *AbyssRecording `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type JournalEntry_Loot struct {
+	Loot *LootRecord `protobuf:"bytes,3,opt,name=loot,proto3,oneof"`
+}
+
+type JournalEntry_CombatLog struct {
+	CombatLog *combatlog.CombatLogRecord `protobuf:"bytes,4,opt,name=combat_log,json=combatLog,proto3,oneof"`
+}
+
+type JournalEntry_CombatLogOffset struct {
+	CombatLogOffset *CombatLogOffset `protobuf:"bytes,5,opt,name=combat_log_offset,json=combatLogOffset,proto3,oneof"`
+}
+
+type JournalEntry_CapturedFrame struct {
+	CapturedFrame *CapturedFrame `protobuf:"bytes,6,opt,name=captured_frame,json=capturedFrame,proto3,oneof"`
+}
+
+func (*JournalEntry_Metadata) isJournalEntry_Entry() {}
+
+func (*JournalEntry_Loot) isJournalEntry_Entry() {}
+
+func (*JournalEntry_CombatLog) isJournalEntry_Entry() {}
+
+func (*JournalEntry_CombatLogOffset) isJournalEntry_Entry() {}
+
+func (*JournalEntry_CapturedFrame) isJournalEntry_Entry() {}
+
+// CapturedFrame is single overview frame with its capture time.
+type CapturedFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// image is frame encoded as GIF image.
+	Image []byte `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// captured_at is wall-clock time of capture in unix milliseconds.
+	CapturedAt int64 `protobuf:"varint,2,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`
+}
+
+func (x *CapturedFrame) Reset() {
+	*x = CapturedFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_journal_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CapturedFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturedFrame) ProtoMessage() {}
+
+func (x *CapturedFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_journal_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturedFrame.ProtoReflect.Descriptor instead.
+func (*CapturedFrame) Descriptor() ([]byte, []int) {
+	return file_journal_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CapturedFrame) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *CapturedFrame) GetCapturedAt() int64 {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return 0
+}
+
+// CombatLogOffset marks where combat log of character started when recording began.
+type CombatLogOffset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CharacterName string                 `protobuf:"bytes,1,opt,name=character_name,json=characterName,proto3" json:"character_name,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	LanguageCode  combatlog.LanguageCode `protobuf:"varint,4,opt,name=language_code,json=languageCode,proto3,enum=combatlog.LanguageCode" json:"language_code,omitempty"`
+}
+
+func (x *CombatLogOffset) Reset() {
+	*x = CombatLogOffset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_journal_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CombatLogOffset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CombatLogOffset) ProtoMessage() {}
+
+func (x *CombatLogOffset) ProtoReflect() protoreflect.Message {
+	mi := &file_journal_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CombatLogOffset.ProtoReflect.Descriptor instead.
+func (*CombatLogOffset) Descriptor() ([]byte, []int) {
+	return file_journal_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CombatLogOffset) GetCharacterName() string {
+	if x != nil {
+		return x.CharacterName
+	}
+	return ""
+}
+
+func (x *CombatLogOffset) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *CombatLogOffset) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *CombatLogOffset) GetLanguageCode() combatlog.LanguageCode {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return combatlog.LanguageCode(0)
+}
+
+var File_journal_proto protoreflect.FileDescriptor
+
+var file_journal_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x1a, 0x0f, 0x61, 0x62, 0x79, 0x73, 0x73,
+	0x66, 0x69, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x63, 0x6f, 0x6d, 0x62,
+	0x61, 0x74, 0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc9, 0x02, 0x0a, 0x0c,
+	0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x36, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x62, 0x79, 0x73, 0x73, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a, 0x04, 0x6c, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x6f,
+	0x6f, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x48, 0x00, 0x52, 0x04, 0x6c, 0x6f, 0x6f, 0x74,
+	0x12, 0x3b, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x6c, 0x6f, 0x67,
+	0x2e, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x47, 0x0a,
+	0x11, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x4c, 0x6f, 0x67,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72,
+	0x65, 0x64, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72,
+	0x65, 0x64, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x64, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72,
+	0x79, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x46, 0x0a, 0x0d, 0x43, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x64, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x64, 0x41, 0x74, 0x22,
+	0xaa, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x68, 0x61,
+	0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x3c,
+	0x0a, 0x0d, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x6c, 0x6f,
+	0x67, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x0c,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x42, 0x2f, 0x5a, 0x2d,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x68, 0x69, 0x76, 0x61,
+	0x73, 0x2f, 0x61, 0x62, 0x79, 0x73, 0x73, 0x2d, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x62, 0x6f, 0x78,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_journal_proto_rawDescOnce sync.Once
+	file_journal_proto_rawDescData = file_journal_proto_rawDesc
+)
+
+func file_journal_proto_rawDescGZIP() []byte {
+	file_journal_proto_rawDescOnce.Do(func() {
+		file_journal_proto_rawDescData = protoimpl.X.CompressGZIP(file_journal_proto_rawDescData)
+	})
+	return file_journal_proto_rawDescData
+}
+
+var file_journal_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_journal_proto_goTypes = []interface{}{
+	(*JournalEntry)(nil),              // 0: protobuf.JournalEntry
+	(*CapturedFrame)(nil),             // 1: protobuf.CapturedFrame
+	(*CombatLogOffset)(nil),           // 2: protobuf.CombatLogOffset
+	(*AbyssRecording)(nil),            // 3: protobuf.AbyssRecording
+	(*LootRecord)(nil),                // 4: protobuf.LootRecord
+	(*combatlog.CombatLogRecord)(nil), // 5: combatlog.CombatLogRecord
+	(combatlog.LanguageCode)(0),       // 6: combatlog.LanguageCode
+}
+var file_journal_proto_depIdxs = []int32{
+	3, // 0: protobuf.JournalEntry.metadata:type_name -> protobuf.AbyssRecording
+	4, // 1: protobuf.JournalEntry.loot:type_name -> protobuf.LootRecord
+	5, // 2: protobuf.JournalEntry.combat_log:type_name -> combatlog.CombatLogRecord
+	2, // 3: protobuf.JournalEntry.combat_log_offset:type_name -> protobuf.CombatLogOffset
+	1, // 4: protobuf.JournalEntry.captured_frame:type_name -> protobuf.CapturedFrame
+	6, // 5: protobuf.CombatLogOffset.language_code:type_name -> combatlog.LanguageCode
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_journal_proto_init() }
+func file_journal_proto_init() {
+	if File_journal_proto != nil {
+		return
+	}
+	file_abyssfile_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_journal_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JournalEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_journal_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CapturedFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_journal_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CombatLogOffset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_journal_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*JournalEntry_Metadata)(nil),
+		(*JournalEntry_Loot)(nil),
+		(*JournalEntry_CombatLog)(nil),
+		(*JournalEntry_CombatLogOffset)(nil),
+		(*JournalEntry_CapturedFrame)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_journal_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_journal_proto_goTypes,
+		DependencyIndexes: file_journal_proto_depIdxs,
+		MessageInfos:      file_journal_proto_msgTypes,
+	}.Build()
+	File_journal_proto = out.File
+	file_journal_proto_rawDesc = nil
+	file_journal_proto_goTypes = nil
+	file_journal_proto_depIdxs = nil
+}
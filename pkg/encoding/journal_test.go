@@ -0,0 +1,132 @@
+package encoding
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shivas/abyss-blackbox/pkg/combatlog"
+)
+
+func TestJournal(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "run.abyss"+JournalExtension)
+
+	j, err := CreateJournal(filename, &AbyssRecording{RecorderVersion: "test", LootRecordDiscriminator: "Quafe"})
+	if err != nil {
+		t.Fatalf("CreateJournal() error = %v", err)
+	}
+
+	frame := image.NewPaletted(image.Rect(0, 0, 20, 10), []color.Color{color.White, color.Black})
+	frame.SetColorIndex(3, 3, 1)
+
+	started := time.Date(2020, 10, 22, 20, 59, 44, 0, time.UTC)
+
+	steps := []func() error{
+		func() error { return j.AppendLoot(&LootRecord{Frame: 0, Loot: "initial"}) },
+		func() error { return j.AppendFrame(frame, started) },
+		func() error { return j.AppendFrame(frame, started.Add(2*time.Second)) },
+		func() error { return j.AppendMetadata(&AbyssRecording{WeatherStrength: 50}) },
+		func() error { return j.AppendLoot(&LootRecord{Frame: 1, Loot: "after"}) },
+		func() error {
+			return j.AppendCombatLogOffset(&CombatLogOffset{CharacterName: "Runner1", Filename: "log.txt", Offset: 123})
+		},
+		func() error {
+			return j.AppendCombatLog(&combatlog.CombatLogRecord{CharacterName: "Runner1", CombatLogLines: []string{"line1"}})
+		},
+		func() error {
+			return j.AppendCombatLog(&combatlog.CombatLogRecord{CharacterName: "Runner1", CombatLogLines: []string{"line2"}})
+		},
+	}
+
+	for i, step := range steps {
+		if err = step(); err != nil {
+			t.Fatalf("step %d error = %v", i, err)
+		}
+	}
+
+	if err = j.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	journal, err := ReadJournalFile(filename)
+	if err != nil {
+		t.Fatalf("ReadJournalFile() error = %v", err)
+	}
+
+	if journal.Truncated || len(journal.Frames) != 2 || len(journal.CombatLogOffsets) != 1 {
+		t.Errorf("ReadJournalFile() truncated = %t, frames = %d, offsets = %d", journal.Truncated, len(journal.Frames), len(journal.CombatLogOffsets))
+	}
+
+	rec, err := journal.Finalize(OverviewFormatGIF)
+	if err != nil {
+		t.Fatalf("Journal.Finalize() error = %v", err)
+	}
+
+	if rec.GetRecorderVersion() != "test" || rec.GetWeatherStrength() != 50 || len(rec.GetLoot()) != 2 {
+		t.Errorf("Journal.Finalize() unexpected metadata: %v", rec)
+	}
+
+	if len(rec.GetCombatLog()) != 1 || len(rec.GetCombatLog()[0].GetCombatLogLines()) != 2 {
+		t.Errorf("Journal.Finalize() unexpected combat log: %v", rec.GetCombatLog())
+	}
+
+	anim, err := gif.DecodeAll(bytes.NewReader(rec.GetOverview()))
+	if err != nil {
+		t.Fatalf("overview is not valid GIF: %v", err)
+	}
+
+	if len(anim.Image) != 2 || anim.Image[1].ColorIndexAt(3, 3) != 1 {
+		t.Errorf("unexpected overview frames: %d", len(anim.Image))
+	}
+
+	// second frame came 2 seconds later, so dropped frame is kept on timeline
+	if anim.Delay[0] != 2*FrameDelay {
+		t.Errorf("first frame delay = %d, want %d", anim.Delay[0], 2*FrameDelay)
+	}
+
+	if at, ok := rec.FrameTime(1); !ok || !at.Equal(started.Add(2*time.Second)) {
+		t.Errorf("AbyssRecording.FrameTime(1) = %s, %t", at, ok)
+	}
+
+	journal, err = ReadJournalFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err = journal.Finalize(OverviewFormatFrames)
+	if err != nil {
+		t.Fatalf("Journal.Finalize() error = %v", err)
+	}
+
+	if count, errr := rec.FrameCount(); errr != nil || count != 2 || len(rec.GetOverview()) != 0 {
+		t.Errorf("Journal.Finalize() compact overview frames = %d, %v", count, errr)
+	}
+
+	if rec.GetOverviewFrames().Offset(1) != 2*time.Second {
+		t.Errorf("compact overview frame offset = %s, want 2s", rec.GetOverviewFrames().Offset(1))
+	}
+
+	// simulate crash in the middle of writing last entry
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	journal, err = ReadJournal(bytes.NewReader(data[:len(data)-3]))
+	if err != nil {
+		t.Fatalf("ReadJournal() of truncated journal error = %v", err)
+	}
+
+	if !journal.Truncated || len(journal.Frames) != 2 || len(journal.Recording.GetCombatLog()[0].GetCombatLogLines()) != 1 {
+		t.Errorf("ReadJournal() of truncated journal = %+v", journal)
+	}
+
+	if _, err = ReadJournal(bytes.NewReader([]byte("not a journal"))); err != ErrNotJournal {
+		t.Errorf("ReadJournal() of garbage error = %v, want %v", err, ErrNotJournal)
+	}
+}
@@ -0,0 +1,352 @@
+package encoding
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// FrameInterval is interval between overview frames captured by recorder.
+const FrameInterval = time.Second
+
+// DefaultKeyframeInterval is distance between keyframes, decoding random frame needs at most this many frames decoded.
+const DefaultKeyframeInterval = 60
+
+// ErrNoOverview returned when recording has neither overview GIF nor overview frames.
+var ErrNoOverview = errors.New("recording has no overview")
+
+// maxFrameSide limits width and height of overview frames read from recording, captured overview is far smaller.
+const maxFrameSide = 4096
+
+// FrameEncoder builds OverviewFrames out of paletted frames.
+type FrameEncoder struct {
+	frames *OverviewFrames
+	prev   []uint8
+	diff   []uint8
+}
+
+// NewFrameEncoder creates encoder writing keyframe every keyframeInterval frames.
+func NewFrameEncoder(keyframeInterval int) *FrameEncoder {
+	if keyframeInterval <= 0 {
+		keyframeInterval = DefaultKeyframeInterval
+	}
+
+	return &FrameEncoder{frames: &OverviewFrames{KeyframeInterval: int32(keyframeInterval)}}
+}
+
+// Add encodes frame captured at offset since first frame, all frames must have same size and palette.
+func (e *FrameEncoder) Add(img *image.Paletted, offset time.Duration) error {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	pix := make([]uint8, 0, w*h)
+
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		start := img.PixOffset(img.Rect.Min.X, y)
+		pix = append(pix, img.Pix[start:start+w]...)
+	}
+
+	if e.prev == nil {
+		e.frames.Width = int32(w)
+		e.frames.Height = int32(h)
+
+		for _, c := range img.Palette {
+			e.frames.Palette = append(e.frames.Palette, packColor(c))
+		}
+	} else if int32(w) != e.frames.Width || int32(h) != e.frames.Height {
+		return fmt.Errorf("frame size %dx%d differs from first frame %dx%d", w, h, e.frames.Width, e.frames.Height)
+	}
+
+	frame := &OverviewFrame{OffsetMs: offset.Milliseconds()}
+
+	if len(e.frames.Frames)%int(e.frames.KeyframeInterval) == 0 {
+		frame.Keyframe = true
+		frame.Runs = encodeRuns(pix)
+	} else {
+		if cap(e.diff) < len(pix) {
+			e.diff = make([]uint8, len(pix))
+		}
+
+		e.diff = e.diff[:len(pix)]
+
+		for i := range pix {
+			e.diff[i] = pix[i] ^ e.prev[i]
+		}
+
+		frame.Runs = encodeRuns(e.diff)
+	}
+
+	e.frames.Frames = append(e.frames.Frames, frame)
+	e.prev = pix
+
+	return nil
+}
+
+// Frames returns encoded frames.
+func (e *FrameEncoder) Frames() *OverviewFrames {
+	return e.frames
+}
+
+// OverviewFramesFromGIF converts animated GIF overview into OverviewFrames, frame offsets are taken from GIF delays.
+func OverviewFramesFromGIF(data []byte, keyframeInterval int) (*OverviewFrames, error) {
+	anim, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	e := NewFrameEncoder(keyframeInterval)
+
+	var offset time.Duration
+
+	for i, img := range anim.Image {
+		if err = e.Add(img, offset); err != nil {
+			return nil, err
+		}
+
+		offset += time.Duration(anim.Delay[i]) * FrameInterval / FrameDelay
+	}
+
+	return e.Frames(), nil
+}
+
+// Len returns number of frames.
+func (of *OverviewFrames) Len() int {
+	return len(of.GetFrames())
+}
+
+// Offset returns capture time of frame n since first frame.
+func (of *OverviewFrames) Offset(n int) time.Duration {
+	return time.Duration(of.GetFrames()[n].GetOffsetMs()) * time.Millisecond
+}
+
+// Frame decodes frame n, only frames since preceding keyframe are decoded.
+func (of *OverviewFrames) Frame(n int) (*image.Paletted, error) {
+	if n < 0 || n >= of.Len() {
+		return nil, fmt.Errorf("frame %d out of range, recording has %d frames", n, of.Len())
+	}
+
+	if err := of.checkSize(); err != nil {
+		return nil, err
+	}
+
+	key := n
+	for key > 0 && !of.Frames[key].GetKeyframe() {
+		key--
+	}
+
+	img := of.newImage()
+
+	for i := key; i <= n; i++ {
+		if err := of.apply(img.Pix, i); err != nil {
+			return nil, err
+		}
+	}
+
+	return img, nil
+}
+
+// ToGIF converts frames into animated GIF as written by recorder, frame delays follow capture times of frames.
+func (of *OverviewFrames) ToGIF() ([]byte, error) {
+	if err := of.checkSize(); err != nil {
+		return nil, err
+	}
+
+	anim := gif.GIF{LoopCount: -1}
+	pix := make([]uint8, of.Width*of.Height)
+	offsets := make([]int64, 0, of.Len())
+
+	for _, frame := range of.Frames {
+		offsets = append(offsets, frame.GetOffsetMs())
+	}
+
+	for i := range of.Frames {
+		if err := of.apply(pix, i); err != nil {
+			return nil, err
+		}
+
+		img := of.newImage()
+		copy(img.Pix, pix)
+
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, frameDelay(offsets, i))
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, &anim); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// frameDelay returns GIF delay of frame i, keeping playback speed constant when frames were dropped during capture.
+func frameDelay(timestamps []int64, i int) int {
+	if timestamps == nil || i+1 >= len(timestamps) {
+		return FrameDelay
+	}
+
+	gap := time.Duration(timestamps[i+1]-timestamps[i]) * time.Millisecond
+	delay := int((gap*FrameDelay + FrameInterval/2) / FrameInterval)
+
+	if delay < 1 {
+		return 1
+	}
+
+	return delay
+}
+
+// checkSize validates frame size stored in recording against sane maximum and pixels of first frame,
+// before any pixels are allocated.
+func (of *OverviewFrames) checkSize() error {
+	w, h := of.GetWidth(), of.GetHeight()
+	if w <= 0 || h <= 0 || w > maxFrameSide || h > maxFrameSide {
+		return fmt.Errorf("%w: overview frame size %dx%d", ErrCorrupted, w, h)
+	}
+
+	if len(of.GetPalette()) == 0 || len(of.GetPalette()) > 256 {
+		return fmt.Errorf("%w: overview palette has %d colours", ErrCorrupted, len(of.GetPalette()))
+	}
+
+	if of.Len() == 0 {
+		return nil
+	}
+
+	first := of.Frames[0]
+	if !first.GetKeyframe() || runsLength(first.GetRuns()) != int(w*h) {
+		return fmt.Errorf("%w: first overview frame doesn't match frame size %dx%d", ErrCorrupted, w, h)
+	}
+
+	return nil
+}
+
+// runsLength returns number of pixels covered by runs, -1 when runs are malformed.
+func runsLength(runs []byte) int {
+	length := 0
+
+	for len(runs) > 0 {
+		count, n := protowire.ConsumeVarint(runs)
+		if n < 0 || len(runs) < n+1 || count > maxFrameSide*maxFrameSide {
+			return -1
+		}
+
+		length += int(count)
+		if length > maxFrameSide*maxFrameSide {
+			return -1
+		}
+
+		runs = runs[n+1:]
+	}
+
+	return length
+}
+
+func (of *OverviewFrames) newImage() *image.Paletted {
+	palette := make(color.Palette, 0, len(of.Palette))
+	for _, c := range of.Palette {
+		palette = append(palette, unpackColor(c))
+	}
+
+	return image.NewPaletted(image.Rect(0, 0, int(of.Width), int(of.Height)), palette)
+}
+
+// apply decodes frame i on top of pixels of previous frame.
+func (of *OverviewFrames) apply(pix []uint8, i int) error {
+	frame := of.Frames[i]
+	runs := frame.GetRuns()
+	pos := 0
+
+	for len(runs) > 0 {
+		count, n := protowire.ConsumeVarint(runs)
+		if n < 0 || len(runs) < n+1 || count > uint64(len(pix)-pos) {
+			return fmt.Errorf("frame %d is corrupted", i)
+		}
+
+		value := runs[n]
+		runs = runs[n+1:]
+
+		for end := pos + int(count); pos < end; pos++ {
+			if frame.GetKeyframe() {
+				pix[pos] = value
+			} else {
+				pix[pos] ^= value
+			}
+		}
+	}
+
+	if pos != len(pix) {
+		return fmt.Errorf("frame %d is corrupted", i)
+	}
+
+	return nil
+}
+
+func encodeRuns(pix []uint8) []byte {
+	var runs []byte
+
+	for i := 0; i < len(pix); {
+		j := i + 1
+		for j < len(pix) && pix[j] == pix[i] {
+			j++
+		}
+
+		runs = protowire.AppendVarint(runs, uint64(j-i))
+		runs = append(runs, pix[i])
+		i = j
+	}
+
+	return runs
+}
+
+func packColor(c color.Color) uint32 {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return uint32(rgba.R)<<24 | uint32(rgba.G)<<16 | uint32(rgba.B)<<8 | uint32(rgba.A)
+}
+
+func unpackColor(c uint32) color.Color {
+	return color.RGBA{R: uint8(c >> 24), G: uint8(c >> 16), B: uint8(c >> 8), A: uint8(c)}
+}
+
+// FrameCount returns number of overview frames regardless of how overview is stored.
+func (rf *AbyssRecording) FrameCount() (int, error) {
+	if rf.GetOverviewFrames().Len() > 0 {
+		return rf.OverviewFrames.Len(), nil
+	}
+
+	if len(rf.GetOverview()) == 0 {
+		return 0, ErrNoOverview
+	}
+
+	anim, err := gif.DecodeAll(bytes.NewReader(rf.Overview))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(anim.Image), nil
+}
+
+// OverviewGIF returns overview as animated GIF, converting overview frames if recording has no GIF.
+func (rf *AbyssRecording) OverviewGIF() ([]byte, error) {
+	if len(rf.GetOverview()) > 0 {
+		return rf.Overview, nil
+	}
+
+	if rf.GetOverviewFrames().Len() > 0 {
+		return rf.OverviewFrames.ToGIF()
+	}
+
+	return nil, ErrNoOverview
+}
+
+// FrameTime returns wall-clock capture time of frame n, false for recordings made without frame timestamps.
+func (rf *AbyssRecording) FrameTime(n int) (time.Time, bool) {
+	timestamps := rf.GetFrameTimestamps()
+	if n < 0 || n >= len(timestamps) {
+		return time.Time{}, false
+	}
+
+	return time.UnixMilli(timestamps[n]).UTC(), true
+}
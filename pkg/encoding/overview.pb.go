@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: overview.proto
+
+package encoding
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OverviewFrames stores overview frames as run-length encoded bitmaps, frames between keyframes are stored as difference to previous frame.
+type OverviewFrames struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Width  int32 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// palette colours as 0xRRGGBBAA, pixel value is index into palette.
+	Palette []uint32 `protobuf:"varint,3,rep,packed,name=palette,proto3" json:"palette,omitempty"`
+	// keyframe_interval is maximum distance between keyframes.
+	KeyframeInterval int32            `protobuf:"varint,4,opt,name=keyframe_interval,json=keyframeInterval,proto3" json:"keyframe_interval,omitempty"`
+	Frames           []*OverviewFrame `protobuf:"bytes,5,rep,name=frames,proto3" json:"frames,omitempty"`
+}
+
+func (x *OverviewFrames) Reset() {
+	*x = OverviewFrames{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_overview_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OverviewFrames) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverviewFrames) ProtoMessage() {}
+
+func (x *OverviewFrames) ProtoReflect() protoreflect.Message {
+	mi := &file_overview_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverviewFrames.ProtoReflect.Descriptor instead.
+func (*OverviewFrames) Descriptor() ([]byte, []int) {
+	return file_overview_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OverviewFrames) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *OverviewFrames) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *OverviewFrames) GetPalette() []uint32 {
+	if x != nil {
+		return x.Palette
+	}
+	return nil
+}
+
+func (x *OverviewFrames) GetKeyframeInterval() int32 {
+	if x != nil {
+		return x.KeyframeInterval
+	}
+	return 0
+}
+
+func (x *OverviewFrames) GetFrames() []*OverviewFrame {
+	if x != nil {
+		return x.Frames
+	}
+	return nil
+}
+
+type OverviewFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// offset_ms is time of frame capture in milliseconds since first frame.
+	OffsetMs int64 `protobuf:"varint,1,opt,name=offset_ms,json=offsetMs,proto3" json:"offset_ms,omitempty"`
+	// keyframe frames hold full image, other frames hold XOR with previous frame.
+	Keyframe bool `protobuf:"varint,2,opt,name=keyframe,proto3" json:"keyframe,omitempty"`
+	// runs is sequence of (varint run length, pixel byte) pairs covering frame row by row.
+	Runs []byte `protobuf:"bytes,3,opt,name=runs,proto3" json:"runs,omitempty"`
+}
+
+func (x *OverviewFrame) Reset() {
+	*x = OverviewFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_overview_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OverviewFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverviewFrame) ProtoMessage() {}
+
+func (x *OverviewFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_overview_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverviewFrame.ProtoReflect.Descriptor instead.
+func (*OverviewFrame) Descriptor() ([]byte, []int) {
+	return file_overview_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OverviewFrame) GetOffsetMs() int64 {
+	if x != nil {
+		return x.OffsetMs
+	}
+	return 0
+}
+
+func (x *OverviewFrame) GetKeyframe() bool {
+	if x != nil {
+		return x.Keyframe
+	}
+	return false
+}
+
+func (x *OverviewFrame) GetRuns() []byte {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+var File_overview_proto protoreflect.FileDescriptor
+
+var file_overview_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x22, 0xb6, 0x01, 0x0a, 0x0e, 0x4f,
+	0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x61, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x70, 0x61,
+	0x6c, 0x65, 0x74, 0x74, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x6b, 0x65, 0x79, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x10, 0x6b, 0x65, 0x79, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4f, 0x76,
+	0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x52, 0x06, 0x66, 0x72, 0x61,
+	0x6d, 0x65, 0x73, 0x22, 0x5c, 0x0a, 0x0d, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x6d,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x4d,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x72, 0x75, 0x6e,
+	0x73, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x73, 0x68, 0x69, 0x76, 0x61, 0x73, 0x2f, 0x61, 0x62, 0x79, 0x73, 0x73, 0x2d, 0x62, 0x6c, 0x61,
+	0x63, 0x6b, 0x62, 0x6f, 0x78, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69,
+	0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_overview_proto_rawDescOnce sync.Once
+	file_overview_proto_rawDescData = file_overview_proto_rawDesc
+)
+
+func file_overview_proto_rawDescGZIP() []byte {
+	file_overview_proto_rawDescOnce.Do(func() {
+		file_overview_proto_rawDescData = protoimpl.X.CompressGZIP(file_overview_proto_rawDescData)
+	})
+	return file_overview_proto_rawDescData
+}
+
+var file_overview_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_overview_proto_goTypes = []interface{}{
+	(*OverviewFrames)(nil), // 0: protobuf.OverviewFrames
+	(*OverviewFrame)(nil),  // 1: protobuf.OverviewFrame
+}
+var file_overview_proto_depIdxs = []int32{
+	1, // 0: protobuf.OverviewFrames.frames:type_name -> protobuf.OverviewFrame
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_overview_proto_init() }
+func file_overview_proto_init() {
+	if File_overview_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_overview_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OverviewFrames); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_overview_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OverviewFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_overview_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_overview_proto_goTypes,
+		DependencyIndexes: file_overview_proto_depIdxs,
+		MessageInfos:      file_overview_proto_msgTypes,
+	}.Build()
+	File_overview_proto = out.File
+	file_overview_proto_rawDesc = nil
+	file_overview_proto_goTypes = nil
+	file_overview_proto_depIdxs = nil
+}
@@ -0,0 +1,169 @@
+package encoding
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOverviewFrames(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	palette := []color.Color{color.White, color.Black}
+	current := image.NewPaletted(image.Rect(0, 0, 120, 200), palette)
+
+	var frames []*image.Paletted
+
+	anim := gif.GIF{}
+	encoder := NewFrameEncoder(60)
+
+	for i := 0; i < 130; i++ {
+		// mostly static overview, single row changes every frame
+		row := rnd.Intn(current.Rect.Dy())
+		for x := 0; x < current.Rect.Dx(); x++ {
+			current.SetColorIndex(x, row, uint8(rnd.Intn(2)))
+		}
+
+		frame := image.NewPaletted(current.Rect, palette)
+		copy(frame.Pix, current.Pix)
+		frames = append(frames, frame)
+
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, FrameDelay)
+
+		if err := encoder.Add(frame, time.Duration(i)*FrameInterval); err != nil {
+			t.Fatalf("FrameEncoder.Add() error = %v", err)
+		}
+	}
+
+	of := encoder.Frames()
+
+	tests := []struct {
+		name  string
+		frame int
+	}{
+		{"first keyframe", 0},
+		{"delta frame", 1},
+		{"last frame before keyframe", 59},
+		{"second keyframe", 60},
+		{"last frame", 129},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := of.Frame(tt.frame)
+			if err != nil {
+				t.Fatalf("OverviewFrames.Frame() error = %v", err)
+			}
+
+			if !bytes.Equal(got.Pix, frames[tt.frame].Pix) {
+				t.Errorf("OverviewFrames.Frame(%d) pixels differ from original", tt.frame)
+			}
+
+			if of.Offset(tt.frame) != time.Duration(tt.frame)*time.Second {
+				t.Errorf("OverviewFrames.Offset(%d) = %s", tt.frame, of.Offset(tt.frame))
+			}
+		})
+	}
+
+	if _, err := of.Frame(130); err == nil {
+		t.Errorf("OverviewFrames.Frame() out of range expected error")
+	}
+
+	var original bytes.Buffer
+	if err := gif.EncodeAll(&original, &anim); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := proto.Marshal(of)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(data) >= original.Len() {
+		t.Errorf("overview frames %d bytes, not smaller than GIF %d bytes", len(data), original.Len())
+	}
+
+	rec := &AbyssRecording{OverviewFrames: of}
+
+	if count, errr := rec.FrameCount(); errr != nil || count != 130 {
+		t.Errorf("AbyssRecording.FrameCount() = %d, %v", count, errr)
+	}
+
+	converted, err := rec.OverviewGIF()
+	if err != nil {
+		t.Fatalf("AbyssRecording.OverviewGIF() error = %v", err)
+	}
+
+	decoded, err := gif.DecodeAll(bytes.NewReader(converted))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(decoded.Image) != 130 || !bytes.Equal(decoded.Image[129].Pix, frames[129].Pix) {
+		t.Errorf("AbyssRecording.OverviewGIF() produced %d frames differing from original", len(decoded.Image))
+	}
+
+	roundTrip, err := OverviewFramesFromGIF(original.Bytes(), 60)
+	if err != nil || !proto.Equal(roundTrip, of) {
+		t.Errorf("OverviewFramesFromGIF() error = %v, differs from encoded frames", err)
+	}
+}
+
+func TestOverviewFrames_ToGIF(t *testing.T) {
+	frame := image.NewPaletted(image.Rect(0, 0, 4, 3), []color.Color{color.White, color.Black})
+	encoder := NewFrameEncoder(60)
+
+	// third frame captured late, two seconds after second one
+	for _, offset := range []time.Duration{0, time.Second, 3 * time.Second, 4 * time.Second} {
+		if err := encoder.Add(frame, offset); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := encoder.Frames().ToGIF()
+	if err != nil {
+		t.Fatalf("OverviewFrames.ToGIF() error = %v", err)
+	}
+
+	anim, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{FrameDelay, 2 * FrameDelay, FrameDelay, FrameDelay}; !reflect.DeepEqual(anim.Delay, want) {
+		t.Errorf("GIF delays = %v, want %v", anim.Delay, want)
+	}
+
+	corrupted := []struct {
+		name   string
+		modify func(of *OverviewFrames)
+	}{
+		{"huge size", func(of *OverviewFrames) { of.Width, of.Height = 1<<30, 1<<30 }},
+		{"negative size", func(of *OverviewFrames) { of.Width = -4 }},
+		{"size not matching frames", func(of *OverviewFrames) { of.Height = 4 }},
+		{"no palette", func(of *OverviewFrames) { of.Palette = nil }},
+	}
+
+	for _, tt := range corrupted {
+		t.Run(tt.name, func(t *testing.T) {
+			of := proto.Clone(encoder.Frames()).(*OverviewFrames)
+			tt.modify(of)
+
+			if _, err := of.ToGIF(); !errors.Is(err, ErrCorrupted) {
+				t.Errorf("OverviewFrames.ToGIF() error = %v, want %v", err, ErrCorrupted)
+			}
+
+			if _, err := of.Frame(0); !errors.Is(err, ErrCorrupted) {
+				t.Errorf("OverviewFrames.Frame() error = %v, want %v", err, ErrCorrupted)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package encoding
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image/gif"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RecoveryReport describes what was salvaged from damaged recording and what was lost.
+type RecoveryReport struct {
+	// CompressionError is error of compressed stream, empty when stream was read completely.
+	CompressionError string
+	// BytesRecovered is amount of uncompressed bytes read from damaged file.
+	BytesRecovered int
+	// BytesExpected is uncompressed size stored in container header, 0 for legacy recordings.
+	BytesExpected uint64
+	// ChecksumMismatch is set when recovered data doesn't match checksum stored in container header.
+	ChecksumMismatch bool
+	// RecoveredFields lists fields fully recovered.
+	RecoveredFields []string
+	// TruncatedField is name of field cut in the middle, fields that should follow it are lost.
+	TruncatedField string
+	// CorruptedFields lists fields that were read but could not be decoded.
+	CorruptedFields []string
+	// FramesRecovered is amount of overview frames in recovered recording.
+	FramesRecovered int
+	// OverviewTruncated is set when overview GIF had to be cut to last complete frame.
+	OverviewTruncated bool
+	LootRecords       int
+	CombatLogRecords  int
+}
+
+// Lossless returns true if nothing was lost during recovery.
+func (r *RecoveryReport) Lossless() bool {
+	return r.CompressionError == "" && r.TruncatedField == "" && len(r.CorruptedFields) == 0 && !r.OverviewTruncated && !r.ChecksumMismatch
+}
+
+// Recover salvages as much as possible from truncated or damaged recording produced by AbyssRecording.Encode.
+func Recover(r io.Reader) (*AbyssRecording, *RecoveryReport, error) {
+	report := &RecoveryReport{}
+	br := bufio.NewReader(r)
+
+	header, err := readHeader(br)
+	if err != nil {
+		return nil, report, err
+	}
+
+	comp, err := compressor(header.Codec)
+	if err != nil {
+		return nil, report, err
+	}
+
+	report.BytesExpected = header.Size
+
+	cr, err := comp.NewReader(br)
+	if err != nil {
+		return nil, report, fmt.Errorf("nothing to recover, compressed stream header is unreadable: %w", err)
+	}
+	defer cr.Close()
+
+	data, err := io.ReadAll(cr)
+	if err != nil {
+		report.CompressionError = err.Error()
+	}
+
+	report.BytesRecovered = len(data)
+	report.ChecksumMismatch = !header.Legacy() && crc32.ChecksumIEEE(data) != header.Checksum
+
+	rec := &AbyssRecording{}
+	fields := rec.ProtoReflect().Descriptor().Fields()
+	opts := proto.UnmarshalOptions{Merge: true}
+
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			break
+		}
+
+		name := fieldName(fields, num)
+
+		m := protowire.ConsumeFieldValue(num, typ, data[n:])
+		if m < 0 {
+			report.TruncatedField = name
+
+			// partial overview still holds complete frames
+			if _, l := protowire.ConsumeVarint(data[n:]); typ == protowire.BytesType && l > 0 {
+				switch name {
+				case "overview":
+					rec.Overview = data[n+l:]
+				case "overview_frames":
+					rec.OverviewFrames = salvageOverviewFrames(data[n+l:])
+					report.OverviewTruncated = true
+				}
+			}
+
+			break
+		}
+
+		if err = opts.Unmarshal(data[:n+m], rec); err != nil {
+			report.CorruptedFields = append(report.CorruptedFields, name)
+		} else {
+			report.RecoveredFields = appendUnique(report.RecoveredFields, name)
+		}
+
+		data = data[n+m:]
+	}
+
+	if rec.GetOverviewFrames().Len() > 0 {
+		report.FramesRecovered = rec.OverviewFrames.Len()
+	}
+
+	if len(rec.Overview) > 0 {
+		if anim, errr := gif.DecodeAll(bytes.NewReader(rec.Overview)); errr == nil {
+			report.FramesRecovered = len(anim.Image)
+		} else {
+			report.OverviewTruncated = true
+			rec.Overview, report.FramesRecovered = salvageGIF(rec.Overview)
+		}
+	}
+
+	report.LootRecords = len(rec.Loot)
+	report.CombatLogRecords = len(rec.CombatLog)
+
+	if report.FramesRecovered == 0 && report.LootRecords == 0 && report.CombatLogRecords == 0 {
+		return rec, report, errors.New("no frames, loot or combat log could be recovered")
+	}
+
+	return rec, report, nil
+}
+
+// salvageGIF cuts GIF stream after last complete frame, returning valid GIF and number of frames in it.
+func salvageGIF(data []byte) ([]byte, int) {
+	const (
+		extensionIntroducer = 0x21
+		imageSeparator      = 0x2C
+		trailer             = 0x3B
+		headerLen           = 13 // signature, version and logical screen descriptor
+	)
+
+	if len(data) < headerLen {
+		return nil, 0
+	}
+
+	pos := headerLen
+	if flags := data[10]; flags&0x80 != 0 {
+		pos += 3 * (1 << ((flags & 0x07) + 1)) // global color table
+	}
+
+	var frameEnds []int
+
+	skipSubBlocks := func(p int) int {
+		for p < len(data) {
+			size := int(data[p])
+			p++
+
+			if size == 0 {
+				return p
+			}
+
+			p += size
+		}
+
+		return -1
+	}
+
+loop:
+	for pos < len(data) {
+		switch data[pos] {
+		case extensionIntroducer:
+			if pos+2 > len(data) {
+				break loop
+			}
+
+			if pos = skipSubBlocks(pos + 2); pos < 0 {
+				break loop
+			}
+		case imageSeparator:
+			if pos+10 > len(data) {
+				break loop
+			}
+
+			flags := data[pos+9]
+			pos += 10
+
+			if flags&0x80 != 0 {
+				pos += 3 * (1 << ((flags & 0x07) + 1)) // local color table
+			}
+
+			pos++ // LZW minimum code size
+
+			if pos = skipSubBlocks(pos); pos < 0 {
+				break loop
+			}
+
+			frameEnds = append(frameEnds, pos)
+		default:
+			break loop
+		}
+	}
+
+	// last complete frame could still be damaged, so try few frames back
+	for i := len(frameEnds) - 1; i >= 0 && i >= len(frameEnds)-3; i-- {
+		candidate := make([]byte, 0, frameEnds[i]+1)
+		candidate = append(candidate, data[:frameEnds[i]]...)
+		candidate = append(candidate, trailer)
+
+		if anim, err := gif.DecodeAll(bytes.NewReader(candidate)); err == nil {
+			return candidate, len(anim.Image)
+		}
+	}
+
+	return nil, 0
+}
+
+// salvageOverviewFrames decodes complete fields of truncated OverviewFrames message, losing only frame that was cut.
+func salvageOverviewFrames(data []byte) *OverviewFrames {
+	of := &OverviewFrames{}
+	opts := proto.UnmarshalOptions{Merge: true}
+
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			break
+		}
+
+		m := protowire.ConsumeFieldValue(num, typ, data[n:])
+		if m < 0 {
+			break
+		}
+
+		if err := opts.Unmarshal(data[:n+m], of); err != nil {
+			break
+		}
+
+		data = data[n+m:]
+	}
+
+	return of
+}
+
+func fieldName(fields protoreflect.FieldDescriptors, num protowire.Number) string {
+	if fd := fields.ByNumber(num); fd != nil {
+		return string(fd.Name())
+	}
+
+	return fmt.Sprintf("field %d", num)
+}
+
+func appendUnique(s []string, v string) []string {
+	for _, existing := range s {
+		if existing == v {
+			return s
+		}
+	}
+
+	return append(s, v)
+}
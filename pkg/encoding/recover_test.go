@@ -0,0 +1,98 @@
+package encoding
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"math/rand"
+	"testing"
+
+	"github.com/shivas/abyss-blackbox/pkg/combatlog"
+)
+
+func testRecording(t *testing.T) *AbyssRecording {
+	t.Helper()
+
+	rnd := rand.New(rand.NewSource(1))
+	anim := gif.GIF{}
+
+	for i := 0; i < 20; i++ {
+		frame := image.NewPaletted(image.Rect(0, 0, 64, 64), []color.Color{color.White, color.Black})
+		for p := range frame.Pix {
+			frame.Pix[p] = uint8(rnd.Intn(2))
+		}
+
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, FrameDelay)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, &anim); err != nil {
+		t.Fatal(err)
+	}
+
+	return &AbyssRecording{
+		Overview:        buf.Bytes(),
+		Loot:            []*LootRecord{{Frame: 0, Loot: "Quafe\t1\n"}, {Frame: 19, Loot: "Quafe\t1\nTriglavian Survey Database\t3\n"}},
+		CombatLog:       []*combatlog.CombatLogRecord{{CharacterName: "Runner1", CombatLogLines: []string{"line1", "line2"}}},
+		WeatherStrength: 30,
+		RecorderVersion: "test",
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testRecording(t).Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := buf.Bytes()
+
+	tests := []struct {
+		name          string
+		length        int
+		wantLossless  bool
+		wantTruncated string
+		wantErr       bool
+	}{
+		{name: "intact", length: len(encoded), wantLossless: true},
+		{name: "missing gzip trailer", length: len(encoded) - 4},
+		{name: "cut in the middle of overview", length: len(encoded) / 2, wantTruncated: "overview"},
+		{name: "only gzip header", length: 10, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, report, err := Recover(bytes.NewReader(encoded[:tt.length]))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Recover() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if report.Lossless() != tt.wantLossless {
+				t.Errorf("Recover() lossless = %t, want %t, report %+v", report.Lossless(), tt.wantLossless, report)
+			}
+
+			if report.TruncatedField != tt.wantTruncated {
+				t.Errorf("Recover() truncated field = %q, want %q", report.TruncatedField, tt.wantTruncated)
+			}
+
+			anim, err := gif.DecodeAll(bytes.NewReader(rec.GetOverview()))
+			if err != nil {
+				t.Fatalf("recovered overview is not valid GIF: %v", err)
+			}
+
+			if len(anim.Image) != report.FramesRecovered || report.FramesRecovered == 0 {
+				t.Errorf("Recover() frames = %d, report says %d", len(anim.Image), report.FramesRecovered)
+			}
+
+			if tt.wantLossless && (report.FramesRecovered != 20 || report.LootRecords != 2 || report.CombatLogRecords != 1) {
+				t.Errorf("Recover() of intact recording lost data: %+v", report)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package inventory
+
+import (
+	"strings"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+const (
+	// CategoryCharge is category of ammunition, scripts and other charges.
+	CategoryCharge = "Charge"
+	filamentSuffix = "Filament"
+)
+
+// Change is change of item quantity between two inventory snapshots, Quantity is always positive.
+type Change struct {
+	Name     string
+	Group    string
+	Category string
+	Quantity int64
+}
+
+// Delta is difference between two inventory snapshots.
+type Delta struct {
+	// Gained items, loot of the run.
+	Gained []Change
+	// ConsumedAmmo are charges used up.
+	ConsumedAmmo []Change
+	// ConsumedFilaments are filaments activated.
+	ConsumedFilaments []Change
+	// Lost are all other items gone (jettisoned, moved to other container, consumed boosters).
+	Lost []Change
+}
+
+// Empty tells whether inventories were same.
+func (d Delta) Empty() bool {
+	return len(d.Gained) == 0 && len(d.ConsumedAmmo) == 0 && len(d.ConsumedFilaments) == 0 && len(d.Lost) == 0
+}
+
+// Diff computes what changed between before and after snapshot of inventory, changes are sorted by name.
+// Item details (group, category) are taken from whichever snapshot has them.
+func Diff(before, after Inventory) Delta {
+	var d Delta
+
+	quantitiesBefore, quantitiesAfter := before.Quantities(), after.Quantities()
+	names := append(append(Inventory{}, before...), after...).Names()
+
+	for _, name := range names {
+		diff := quantitiesAfter[name] - quantitiesBefore[name]
+		if diff == 0 {
+			continue
+		}
+
+		item, ok := after.item(name)
+		if !ok || item.Group == "" {
+			item, _ = before.item(name)
+		}
+
+		change := Change{Name: name, Group: item.Group, Category: item.Category, Quantity: diff}
+
+		switch {
+		case diff > 0:
+			d.Gained = append(d.Gained, change)
+		case isFilament(item):
+			change.Quantity = -diff
+			d.ConsumedFilaments = append(d.ConsumedFilaments, change)
+		case item.Category == CategoryCharge:
+			change.Quantity = -diff
+			d.ConsumedAmmo = append(d.ConsumedAmmo, change)
+		default:
+			change.Quantity = -diff
+			d.Lost = append(d.Lost, change)
+		}
+	}
+
+	return d
+}
+
+// isFilament tells whether item is abyssal filament, by its name when client copied no group (list view).
+func isFilament(item Item) bool {
+	return strings.Contains(item.Group, filamentSuffix) || strings.HasSuffix(item.Name, " "+filamentSuffix)
+}
+
+// SnapshotDelta is difference between two consecutive inventory snapshots of same character in recording.
+type SnapshotDelta struct {
+	// From and To are indexes of loot records compared.
+	From int
+	To   int
+	// Character cargo was copied from, empty when recorder could not resolve it.
+	Character string
+	// Frame and Timestamp of later loot record.
+	Frame     int32
+	Timestamp int64
+	Delta
+}
+
+// RecordingDeltas computes differences between consecutive loot records of recording, every character's cargo
+// is compared with its own previous snapshot. Loot records that are not inventory copies (other text copied
+// to clipboard during run) are skipped.
+func RecordingDeltas(loot []*encoding.LootRecord) []SnapshotDelta {
+	type snapshot struct {
+		inv   Inventory
+		index int
+	}
+
+	var (
+		deltas   []SnapshotDelta
+		previous = make(map[string]snapshot)
+	)
+
+	for i, lr := range loot {
+		inv, err := Parse(lr.GetLoot())
+		if err != nil {
+			continue
+		}
+
+		if prev, ok := previous[lr.GetCharacter()]; ok {
+			deltas = append(deltas, SnapshotDelta{
+				From:      prev.index,
+				To:        i,
+				Character: lr.GetCharacter(),
+				Frame:     lr.GetFrame(),
+				Timestamp: lr.GetTimestamp(),
+				Delta:     Diff(prev.inv, inv),
+			})
+		}
+
+		previous[lr.GetCharacter()] = snapshot{inv: inv, index: i}
+	}
+
+	return deltas
+}
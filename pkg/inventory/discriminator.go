@@ -0,0 +1,35 @@
+package inventory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseDiscriminatorQuantities parses quantities of loot record discriminator carried by each character,
+// written as comma separated "Character=quantity" pairs ("Runner One=1, Runner Two=2").
+func ParseDiscriminatorQuantities(text string) (map[string]int64, error) {
+	quantities := make(map[string]int64)
+
+	for _, pair := range strings.Split(text, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		character, quantity, ok := strings.Cut(pair, "=")
+		character = strings.TrimSpace(character)
+
+		if !ok || character == "" {
+			return nil, fmt.Errorf("invalid discriminator quantity %q, expected Character=quantity", strings.TrimSpace(pair))
+		}
+
+		q, err := strconv.ParseInt(strings.TrimSpace(quantity), 10, 64)
+		if err != nil || q <= 0 {
+			return nil, fmt.Errorf("invalid discriminator quantity of %s: %q", character, strings.TrimSpace(quantity))
+		}
+
+		quantities[character] = q
+	}
+
+	return quantities, nil
+}
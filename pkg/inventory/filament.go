@@ -0,0 +1,119 @@
+package inventory
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+// filamentTiers maps filament name prefix to abyss tier.
+var filamentTiers = map[string]int{
+	"Tranquil":    0,
+	"Calm":        1,
+	"Agitated":    2,
+	"Fierce":      3,
+	"Raging":      4,
+	"Chaotic":     5,
+	"Cataclysmic": 6,
+}
+
+// filamentWeathers are weathers named in filament names, as used by abyss type chooser.
+var filamentWeathers = []string{"Dark", "Electrical", "Exotic", "Firestorm", "Gamma"}
+
+// shipGroupClasses maps inventory group of ship allowed into abyssal deadspace to its ship class.
+var shipGroupClasses = map[int32]encoding.AbyssRecording_AbyssShipType{
+	25:   encoding.AbyssRecording_FRIGATE,   // Frigate
+	324:  encoding.AbyssRecording_FRIGATE,   // Assault Frigate
+	830:  encoding.AbyssRecording_FRIGATE,   // Covert Ops
+	831:  encoding.AbyssRecording_FRIGATE,   // Interceptor
+	834:  encoding.AbyssRecording_FRIGATE,   // Stealth Bomber
+	893:  encoding.AbyssRecording_FRIGATE,   // Electronic Attack Ship
+	1283: encoding.AbyssRecording_FRIGATE,   // Expedition Frigate
+	1527: encoding.AbyssRecording_FRIGATE,   // Logistics Frigate
+	420:  encoding.AbyssRecording_DESTROYER, // Destroyer
+	541:  encoding.AbyssRecording_DESTROYER, // Interdictor
+	1305: encoding.AbyssRecording_DESTROYER, // Tactical Destroyer
+	1534: encoding.AbyssRecording_DESTROYER, // Command Destroyer
+	26:   encoding.AbyssRecording_CRUISER,   // Cruiser
+	358:  encoding.AbyssRecording_CRUISER,   // Heavy Assault Cruiser
+	832:  encoding.AbyssRecording_CRUISER,   // Logistics
+	833:  encoding.AbyssRecording_CRUISER,   // Force Recon Ship
+	894:  encoding.AbyssRecording_CRUISER,   // Heavy Interdiction Cruiser
+	906:  encoding.AbyssRecording_CRUISER,   // Combat Recon Ship
+	963:  encoding.AbyssRecording_CRUISER,   // Strategic Cruiser
+	1972: encoding.AbyssRecording_CRUISER,   // Flag Cruiser
+}
+
+// ShipClass returns ship class of ship by its inventory group, UNKNOWN_SHIP for groups not allowed into abyss or unknown.
+func ShipClass(groupID int32) encoding.AbyssRecording_AbyssShipType {
+	return shipGroupClasses[groupID]
+}
+
+// AbyssType is type of abyssal deadspace run entered by activating filament.
+type AbyssType struct {
+	Filament string
+	Tier     int
+	Weather  string
+	ShipType encoding.AbyssRecording_AbyssShipType
+}
+
+// String returns human readable abyss type, like "Cruiser T5 Electrical", or "T5 Electrical" when ship class is unknown.
+func (t AbyssType) String() string {
+	var ship string
+
+	switch t.ShipType {
+	case encoding.AbyssRecording_CRUISER:
+		ship = "Cruiser "
+	case encoding.AbyssRecording_DESTROYER:
+		ship = "Destroyers "
+	case encoding.AbyssRecording_FRIGATE:
+		ship = "Frigates "
+	}
+
+	return fmt.Sprintf("%sT%d %s", ship, t.Tier, t.Weather)
+}
+
+// Matches tells whether abyss type has same tier, weather and ship type. Unknown ship class matches any.
+func (t AbyssType) Matches(shipType encoding.AbyssRecording_AbyssShipType, tier int, weather string) bool {
+	return (t.ShipType == encoding.AbyssRecording_UNKNOWN_SHIP || t.ShipType == shipType) && t.Tier == tier && t.Weather == weather
+}
+
+// ParseFilament returns tier and weather of filament by its English name ("Chaotic Electrical Filament").
+func ParseFilament(name string) (tier int, weather string, ok bool) {
+	fields := strings.Fields(name)
+	if len(fields) != 3 || fields[2] != filamentSuffix {
+		return 0, "", false
+	}
+
+	tier, ok = filamentTiers[fields[0]]
+	if !ok {
+		return 0, "", false
+	}
+
+	for _, w := range filamentWeathers {
+		if w == fields[1] {
+			return tier, w, true
+		}
+	}
+
+	return 0, "", false
+}
+
+// DetectAbyssType identifies tier and weather of abyss by filament consumed between cargo snapshots taken before
+// and inside abyss. Number of filaments consumed doesn't tell ship class, ship type is left unknown.
+// Detection fails when other than one kind of filament was consumed, or filament name is not English.
+func DetectAbyssType(d Delta) (AbyssType, bool) {
+	if len(d.ConsumedFilaments) != 1 {
+		return AbyssType{}, false
+	}
+
+	consumed := d.ConsumedFilaments[0]
+
+	tier, weather, ok := ParseFilament(consumed.Name)
+	if !ok {
+		return AbyssType{}, false
+	}
+
+	return AbyssType{Filament: consumed.Name, Tier: tier, Weather: weather}, true
+}
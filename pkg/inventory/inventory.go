@@ -0,0 +1,240 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrNotInventory returned when text is not copied from inventory window.
+var ErrNotInventory = errors.New("not an inventory copy")
+
+// Item is stack of items copied from inventory window.
+// List view copies name and quantity only, detail view adds group, category, volume and estimated price of stack.
+type Item struct {
+	Name     string
+	Quantity int64
+	Group    string
+	Category string
+	// Volume of stack in m3, zero when not copied.
+	Volume float64
+	// Price is estimated price of stack in ISK, zero when not copied.
+	Price float64
+}
+
+// Inventory is content of inventory window, stacks in order they were copied.
+type Inventory []Item
+
+// Parse parses text copied from inventory window (CTRL+A, CTRL+C) in list or detail view.
+// Every line is one stack: name and quantity separated by tab, followed by detail columns in detail view.
+// Quantity is empty for assembled items, such stack counts as one item.
+func Parse(text string) (Inventory, error) {
+	var inv Inventory
+
+	for i, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		item, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrNotInventory, i+1, err)
+		}
+
+		inv = append(inv, item)
+	}
+
+	if len(inv) == 0 {
+		return nil, fmt.Errorf("%w: no items", ErrNotInventory)
+	}
+
+	return inv, nil
+}
+
+func parseLine(line string) (Item, error) {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 2 {
+		return Item{}, errors.New("quantity missing")
+	}
+
+	item := Item{Name: strings.TrimSpace(fields[0]), Quantity: 1}
+	if item.Name == "" {
+		return Item{}, errors.New("name missing")
+	}
+
+	if quantity := strings.TrimSpace(fields[1]); quantity != "" {
+		q, err := parseQuantity(quantity)
+		if err != nil {
+			return Item{}, err
+		}
+
+		item.Quantity = q
+	}
+
+	// detail columns: group, category, size, slot, volume, estimated price; size and slot are often empty
+	var text []string
+
+	for _, field := range fields[2:] {
+		field = strings.TrimSpace(field)
+
+		switch {
+		case field == "":
+			continue
+		case isVolume(field):
+			item.Volume, _ = parseDecimal(trimUnit(field, volumeUnits))
+		case isPrice(field):
+			item.Price, _ = parseDecimal(trimUnit(field, priceUnits))
+		default:
+			text = append(text, field)
+		}
+	}
+
+	if len(text) > 0 {
+		item.Group = text[0]
+	}
+
+	if len(text) > 1 {
+		item.Category = text[1]
+	}
+
+	return item, nil
+}
+
+// Quantities returns total quantity of every item, stacks of same item are summed.
+func (inv Inventory) Quantities() map[string]int64 {
+	quantities := make(map[string]int64, len(inv))
+	for _, item := range inv {
+		quantities[item.Name] += item.Quantity
+	}
+
+	return quantities
+}
+
+// Quantity returns total quantity of item.
+func (inv Inventory) Quantity(name string) int64 {
+	var quantity int64
+
+	for _, item := range inv {
+		if item.Name == name {
+			quantity += item.Quantity
+		}
+	}
+
+	return quantity
+}
+
+// Price returns estimated price of inventory in ISK, counting only stacks copied with price.
+func (inv Inventory) Price() float64 {
+	var price float64
+	for _, item := range inv {
+		price += item.Price
+	}
+
+	return price
+}
+
+// Names returns names of items in inventory, sorted.
+func (inv Inventory) Names() []string {
+	quantities := inv.Quantities()
+
+	names := make([]string, 0, len(quantities))
+	for name := range quantities {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// item returns first stack of item, used for its group and category.
+func (inv Inventory) item(name string) (Item, bool) {
+	for _, item := range inv {
+		if item.Name == name {
+			return item, true
+		}
+	}
+
+	return Item{}, false
+}
+
+var (
+	volumeUnits = []string{"m3", "m³"}
+	priceUnits  = []string{"ISK"}
+)
+
+func isVolume(field string) bool {
+	return hasUnit(field, volumeUnits)
+}
+
+func isPrice(field string) bool {
+	return hasUnit(field, priceUnits)
+}
+
+func hasUnit(field string, units []string) bool {
+	for _, unit := range units {
+		if strings.HasSuffix(field, unit) {
+			_, err := parseDecimal(trimUnit(field, units))
+			return err == nil
+		}
+	}
+
+	return false
+}
+
+func trimUnit(field string, units []string) string {
+	for _, unit := range units {
+		field = strings.TrimSuffix(field, unit)
+	}
+
+	return strings.TrimSpace(field)
+}
+
+// digitGroupSeparators are separators of thousands used by client in different languages.
+var digitGroupSeparators = strings.NewReplacer(",", "", ".", "", " ", "", "\u00a0", "", "\u202f", "", "'", "")
+
+// parseQuantity parses whole number with digit group separators of any client language.
+func parseQuantity(s string) (int64, error) {
+	q, err := strconv.ParseInt(digitGroupSeparators.Replace(s), 10, 64)
+	if err != nil || q < 0 {
+		return 0, fmt.Errorf("invalid quantity: %q", s)
+	}
+
+	return q, nil
+}
+
+// parseDecimal parses decimal number written with separators of any client language (1,234.56, 1.234,56, 1 234,56).
+// Separator followed by exactly three digits is taken as digit group separator, unless it is only separator after zero.
+func parseDecimal(s string) (float64, error) {
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "'", "").Replace(s)
+
+	lastComma, lastDot := strings.LastIndex(s, ","), strings.LastIndex(s, ".")
+	decimal := byte(0)
+
+	switch {
+	case lastComma >= 0 && lastDot >= 0:
+		decimal = s[max(lastComma, lastDot)]
+	case lastComma >= 0 || lastDot >= 0:
+		sep := max(lastComma, lastDot)
+		if strings.Count(s, s[sep:sep+1]) == 1 && (len(s)-sep-1 != 3 || s[:sep] == "0") {
+			decimal = s[sep]
+		}
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == decimal:
+			b.WriteByte('.')
+		case c == ',' || c == '.':
+			continue
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return strconv.ParseFloat(b.String(), 64)
+}
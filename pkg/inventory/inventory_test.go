@@ -0,0 +1,254 @@
+package inventory
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/shivas/abyss-blackbox/pkg/encoding"
+)
+
+func readSample(t *testing.T, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		sample  string
+		text    string
+		want    Inventory
+		wantErr error
+	}{
+		{
+			name:   "list view",
+			sample: "list_view.txt",
+			want: Inventory{
+				{Name: "Calm Dark Filament", Quantity: 3},
+				{Name: "Scourge Fury Light Missile", Quantity: 4000},
+				{Name: "Nanite Repair Paste", Quantity: 50},
+				{Name: "Quafe", Quantity: 1},
+			},
+		},
+		{
+			name:   "detail view",
+			sample: "detail_view.txt",
+			want: Inventory{
+				{Name: "Scourge Fury Light Missile", Quantity: 4000, Group: "Advanced Light Missile", Category: "Charge", Volume: 60, Price: 1240000},
+				{Name: "Calm Dark Filament", Quantity: 3, Group: "Abyssal Filaments", Category: "Celestial", Volume: 0.3, Price: 3600000},
+				{Name: "Nanite Repair Paste", Quantity: 50, Group: "Nanite Repair Paste", Category: "Material", Volume: 0.5, Price: 1050000},
+				{Name: "Quafe", Quantity: 1, Group: "General Freight", Category: "Commodity", Volume: 0.1, Price: 1500},
+				{Name: "Gila", Quantity: 1, Group: "Cruiser", Category: "Ship", Volume: 10000, Price: 280000000},
+			},
+		},
+		{
+			name:   "detail view german",
+			sample: "detail_view_german.txt",
+			want: Inventory{
+				{Name: "Ruhiges dunkles Filament", Quantity: 3, Group: "Abyssal-Filamente", Category: "Himmelskörper", Volume: 0.3, Price: 3600000},
+				{Name: "Nanitreparaturpaste", Quantity: 1250, Group: "Nanitreparaturpaste", Category: "Material", Volume: 12.5, Price: 26250000},
+			},
+		},
+		{
+			name: "quantity with spaces",
+			text: "Nanite Repair Paste\t1 250\n",
+			want: Inventory{{Name: "Nanite Repair Paste", Quantity: 1250}},
+		},
+		{name: "empty", text: "\r\n", wantErr: ErrNotInventory},
+		{name: "plain text", text: "o7 fly safe", wantErr: ErrNotInventory},
+		{name: "fitting", text: "[Gila, FFA]\nDrone Damage Amplifier II\n", wantErr: ErrNotInventory},
+		{name: "bad quantity", text: "Quafe\tmany\n", wantErr: ErrNotInventory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := tt.text
+			if tt.sample != "" {
+				text = readSample(t, tt.sample)
+			}
+
+			got, err := Parse(text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   Delta
+	}{
+		{
+			name:   "detail view",
+			before: "detail_view.txt",
+			after:  "detail_view_after.txt",
+			want: Delta{
+				Gained: []Change{
+					{Name: "Decayed Warp Disruptor Mutaplasmid", Group: "Mutaplasmids", Category: "Commodity", Quantity: 1},
+					{Name: "Triglavian Survey Database", Group: "Triglavian Datastreams", Category: "Commodity", Quantity: 12},
+				},
+				ConsumedAmmo:      []Change{{Name: "Scourge Fury Light Missile", Group: "Advanced Light Missile", Category: "Charge", Quantity: 1150}},
+				ConsumedFilaments: []Change{{Name: "Calm Dark Filament", Group: "Abyssal Filaments", Category: "Celestial", Quantity: 1}},
+				Lost:              []Change{{Name: "Nanite Repair Paste", Group: "Nanite Repair Paste", Category: "Material", Quantity: 5}},
+			},
+		},
+		{
+			name:   "list view before, detail view after",
+			before: "list_view.txt",
+			after:  "detail_view_after.txt",
+			want: Delta{
+				Gained: []Change{
+					{Name: "Decayed Warp Disruptor Mutaplasmid", Group: "Mutaplasmids", Category: "Commodity", Quantity: 1},
+					{Name: "Gila", Group: "Cruiser", Category: "Ship", Quantity: 1},
+					{Name: "Triglavian Survey Database", Group: "Triglavian Datastreams", Category: "Commodity", Quantity: 12},
+				},
+				ConsumedAmmo:      []Change{{Name: "Scourge Fury Light Missile", Group: "Advanced Light Missile", Category: "Charge", Quantity: 1150}},
+				ConsumedFilaments: []Change{{Name: "Calm Dark Filament", Group: "Abyssal Filaments", Category: "Celestial", Quantity: 1}},
+				Lost:              []Change{{Name: "Nanite Repair Paste", Group: "Nanite Repair Paste", Category: "Material", Quantity: 5}},
+			},
+		},
+		{
+			name:   "list view filament without group",
+			before: "list_view.txt",
+			after:  "list_view.txt",
+			want:   Delta{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := Parse(readSample(t, tt.before))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			after, err := Parse(readSample(t, tt.after))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := Diff(before, after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordingDeltas(t *testing.T) {
+	loot := []*encoding.LootRecord{
+		{Frame: 0, Loot: "Calm Dark Filament\t2\nQuafe\t1\n"},
+		{Frame: 30, Loot: "https://abyssal.space"}, // copied during run
+		{Frame: 600, Timestamp: 1683000000000, Loot: "Calm Dark Filament\t1\nQuafe\t1\nTriglavian Survey Database\t12\n"},
+		{Frame: 610, Loot: "Quafe\t2\n", Character: "Runner2"}, // other character's cargo
+		{Frame: 620, Loot: "Quafe\t2\nTriglavian Survey Database\t4\n", Character: "Runner2"},
+	}
+
+	want := []SnapshotDelta{
+		{
+			From: 0, To: 2, Frame: 600, Timestamp: 1683000000000,
+			Delta: Delta{
+				Gained:            []Change{{Name: "Triglavian Survey Database", Quantity: 12}},
+				ConsumedFilaments: []Change{{Name: "Calm Dark Filament", Quantity: 1}},
+			},
+		},
+		{
+			From: 3, To: 4, Character: "Runner2", Frame: 620,
+			Delta: Delta{Gained: []Change{{Name: "Triglavian Survey Database", Quantity: 4}}},
+		},
+	}
+
+	if got := RecordingDeltas(loot); !reflect.DeepEqual(got, want) {
+		t.Errorf("RecordingDeltas() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDetectAbyssType(t *testing.T) {
+	filament := func(name string, quantity int64) Delta {
+		return Delta{ConsumedFilaments: []Change{{Name: name, Quantity: quantity}}}
+	}
+
+	tests := []struct {
+		name   string
+		delta  Delta
+		want   AbyssType
+		wantOk bool
+	}{
+		{
+			name: "one filament", delta: filament("Chaotic Electrical Filament", 1), wantOk: true,
+			want: AbyssType{Filament: "Chaotic Electrical Filament", Tier: 5, Weather: "Electrical"},
+		},
+		{
+			name: "fleet filaments from one cargo", delta: filament("Tranquil Gamma Filament", 2), wantOk: true,
+			want: AbyssType{Filament: "Tranquil Gamma Filament", Tier: 0, Weather: "Gamma"},
+		},
+		{name: "nothing consumed"},
+		{name: "not english", delta: filament("Ruhiges dunkles Filament", 1)},
+		{name: "not abyssal", delta: filament("Needlejack Filament", 1)},
+		{
+			name:  "two kinds",
+			delta: Delta{ConsumedFilaments: []Change{{Name: "Calm Dark Filament", Quantity: 1}, {Name: "Calm Exotic Filament", Quantity: 1}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DetectAbyssType(tt.delta)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("DetectAbyssType() = %+v, %t, want %+v, %t", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestAbyssType_String(t *testing.T) {
+	tests := []struct {
+		groupID int32
+		want    string
+	}{
+		{groupID: 358, want: "Cruiser T5 Dark"},
+		{groupID: 1305, want: "Destroyers T5 Dark"},
+		{groupID: 25, want: "Frigates T5 Dark"},
+		{groupID: 27, want: "T5 Dark"}, // battleship
+		{want: "T5 Dark"},
+	}
+
+	for _, tt := range tests {
+		if got := (AbyssType{Tier: 5, Weather: "Dark", ShipType: ShipClass(tt.groupID)}).String(); got != tt.want {
+			t.Errorf("String() of ship group %d = %q, want %q", tt.groupID, got, tt.want)
+		}
+	}
+}
+
+func TestParseDiscriminatorQuantities(t *testing.T) {
+	got, err := ParseDiscriminatorQuantities(" Runner One=1, Runner2 = 2,")
+	if err != nil {
+		t.Fatalf("ParseDiscriminatorQuantities() error = %v", err)
+	}
+
+	if want := map[string]int64{"Runner One": 1, "Runner2": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseDiscriminatorQuantities() = %v, want %v", got, want)
+	}
+
+	for _, text := range []string{"Runner1", "Runner1=many", "=1", "Runner1=0"} {
+		if _, err = ParseDiscriminatorQuantities(text); err == nil {
+			t.Errorf("ParseDiscriminatorQuantities(%q) expected error", text)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package prices
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownFormat returned when price file is neither CSV nor JSON.
+var ErrUnknownFormat = errors.New("unknown price file format, expected .csv or .json")
+
+// Entry is ISK price of one unit of item.
+type Entry struct {
+	TypeID int64   `json:"typeID,omitempty"`
+	Name   string  `json:"name"`
+	Price  float64 `json:"price"`
+}
+
+// FileProvider provides prices loaded from local price file, item names are matched case insensitive.
+type FileProvider struct {
+	byName   map[string]Entry
+	byTypeID map[int64]Entry
+}
+
+var _ PriceProvider = (*FileProvider)(nil)
+
+// NewFileProvider creates provider of prices of entries, later entries of same item win.
+func NewFileProvider(entries []Entry) *FileProvider {
+	p := &FileProvider{byName: make(map[string]Entry, len(entries)), byTypeID: make(map[int64]Entry, len(entries))}
+
+	for _, e := range entries {
+		if e.Name != "" {
+			p.byName[strings.ToLower(e.Name)] = e
+		}
+
+		if e.TypeID != 0 {
+			p.byTypeID[e.TypeID] = e
+		}
+	}
+
+	return p
+}
+
+// LoadFile loads prices from CSV or JSON file, format is chosen by file extension.
+func LoadFile(filename string) (*FileProvider, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		entries, err = ReadCSV(f)
+	case ".json":
+		entries, err = ReadJSON(f)
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return NewFileProvider(entries), nil
+}
+
+// ReadCSV reads price entries from CSV with header row naming columns: name and price are required, typeID is optional.
+func ReadCSV(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	columns := map[string]int{"typeid": -1, "name": -1, "price": -1}
+
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if _, ok := columns[column]; ok {
+			columns[column] = i
+		}
+	}
+
+	if columns["name"] < 0 || columns["price"] < 0 {
+		return nil, errors.New("name and price columns are required")
+	}
+
+	var entries []Entry
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(column string) string {
+			if i := columns[column]; i >= 0 && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+
+			return ""
+		}
+
+		e := Entry{Name: field("name")}
+
+		if e.Price, err = strconv.ParseFloat(field("price"), 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid price %q", line, field("price"))
+		}
+
+		if typeID := field("typeid"); typeID != "" {
+			if e.TypeID, err = strconv.ParseInt(typeID, 10, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid typeID %q", line, typeID)
+			}
+		}
+
+		entries = append(entries, e)
+	}
+}
+
+// ReadJSON reads price entries from JSON array of entries, or from JSON object of prices by item name.
+func ReadJSON(r io.Reader) ([]Entry, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(raw, &entries); err == nil {
+		return entries, nil
+	}
+
+	var byName map[string]float64
+	if err := json.Unmarshal(raw, &byName); err != nil {
+		return nil, errors.New("expected array of {typeID, name, price} or object of prices by name")
+	}
+
+	for name, price := range byName {
+		entries = append(entries, Entry{Name: name, Price: price})
+	}
+
+	return entries, nil
+}
+
+// Prices satisfies PriceProvider interface.
+func (p *FileProvider) Prices(_ context.Context, names []string) (Prices, error) {
+	result := make(Prices, len(names))
+
+	for _, name := range names {
+		if e, ok := p.byName[strings.ToLower(name)]; ok {
+			result[name] = e.Price
+		}
+	}
+
+	return result, nil
+}
+
+// PriceByTypeID returns price of item by its type ID.
+func (p *FileProvider) PriceByTypeID(typeID int64) (float64, bool) {
+	e, ok := p.byTypeID[typeID]
+
+	return e.Price, ok
+}
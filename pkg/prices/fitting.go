@@ -0,0 +1,58 @@
+package prices
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// FittingItems returns items of EFT fitting by quantity: ship hull, modules, rigs, subsystems, drones and cargo.
+// Charges loaded in modules are not counted, only charges in cargo ("Item x100") are.
+func FittingItems(eft string) map[string]int64 {
+	items := make(map[string]int64)
+	header := true
+
+	for _, line := range strings.Split(strings.ReplaceAll(eft, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if header {
+			header = false
+
+			// [Ship, Fitting name]
+			if ship, _, found := strings.Cut(strings.Trim(line, "[]"), ","); found && strings.HasPrefix(line, "[") {
+				items[strings.TrimSpace(ship)]++
+				continue
+			}
+		}
+
+		if strings.HasPrefix(line, "[") { // [Empty High slot]
+			continue
+		}
+
+		line = strings.TrimSpace(strings.TrimSuffix(line, "/OFFLINE"))
+		name, quantity := line, int64(1)
+
+		if idx := strings.LastIndex(line, " x"); idx > 0 {
+			if q, err := strconv.ParseInt(line[idx+2:], 10, 64); err == nil && q > 0 {
+				name, quantity = strings.TrimSpace(line[:idx]), q
+			}
+		}
+
+		// module with loaded charge: Module, Charge
+		if module, _, found := strings.Cut(name, ","); found {
+			name = strings.TrimSpace(module)
+		}
+
+		items[name] += quantity
+	}
+
+	return items
+}
+
+// FittingCost returns ISK cost of EFT fitting, with names of items without price.
+func FittingCost(ctx context.Context, p PriceProvider, eft string) (cost float64, missing []string, err error) {
+	return Lookup(ctx, p, FittingItems(eft))
+}
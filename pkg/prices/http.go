@@ -0,0 +1,59 @@
+package prices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HTTPProvider provides prices of price service. Item names are posted as text, one per line,
+// service responds with JSON array of entries, same as JSON price file.
+type HTTPProvider struct {
+	client *http.Client
+	url    string
+}
+
+var _ PriceProvider = (*HTTPProvider)(nil)
+
+// NewHTTPProvider creates provider of prices from price service at url.
+func NewHTTPProvider(client *http.Client, url string) *HTTPProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &HTTPProvider{client: client, url: url}
+}
+
+// Prices satisfies PriceProvider interface.
+func (p *HTTPProvider) Prices(ctx context.Context, names []string) (Prices, error) {
+	if len(names) == 0 {
+		return Prices{}, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, strings.NewReader(strings.Join(names, "\n")))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("price service responded: %s", resp.Status)
+	}
+
+	entries, err := ReadJSON(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decoding prices: %w", err)
+	}
+
+	// service might change letter case of names, match them as price file does
+	return NewFileProvider(entries).Prices(ctx, names)
+}
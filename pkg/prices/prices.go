@@ -0,0 +1,135 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// Prices are ISK prices of one unit of item, by item name.
+type Prices map[string]float64
+
+// PriceProvider provides ISK prices of items.
+type PriceProvider interface {
+	// Prices returns price of every named item provider knows, items without price are left out.
+	Prices(ctx context.Context, names []string) (Prices, error)
+}
+
+// Value returns ISK value of items by quantity and names of items without price, sorted.
+func (p Prices) Value(quantities map[string]int64) (value float64, missing []string) {
+	for name, quantity := range quantities {
+		price, ok := p[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+
+		value += price * float64(quantity)
+	}
+
+	sort.Strings(missing)
+
+	return value, missing
+}
+
+// Lookup asks provider for prices of items and returns their ISK value by quantity, together with names of items without price.
+// When provider fails, value of items priced anyway is returned together with error.
+func Lookup(ctx context.Context, p PriceProvider, quantities map[string]int64) (value float64, missing []string, err error) {
+	names := make([]string, 0, len(quantities))
+	for name := range quantities {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	prices, err := p.Prices(ctx, names)
+	value, missing = prices.Value(quantities)
+
+	return value, missing, err
+}
+
+// NewProvider creates provider of prices from price file, with price service at url asked for items missing in it.
+// Either can be empty, provider is nil when both are.
+func NewProvider(client *http.Client, filename, url string) (PriceProvider, error) {
+	var providers []PriceProvider
+
+	if filename != "" {
+		p, err := LoadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		providers = append(providers, p)
+	}
+
+	if url != "" {
+		providers = append(providers, NewHTTPProvider(client, url))
+	}
+
+	if len(providers) == 0 {
+		return nil, nil
+	}
+
+	return Chain(providers...), nil
+}
+
+// Chain returns provider asking providers in order, every next one only for items previous ones don't know.
+// Errors of providers are returned together with prices known to other providers.
+func Chain(providers ...PriceProvider) PriceProvider {
+	return chain(providers)
+}
+
+type chain []PriceProvider
+
+func (c chain) Prices(ctx context.Context, names []string) (Prices, error) {
+	var (
+		result = make(Prices, len(names))
+		errs   []error
+	)
+
+	for _, p := range c {
+		remaining := make([]string, 0, len(names))
+
+		for _, name := range names {
+			if _, ok := result[name]; !ok {
+				remaining = append(remaining, name)
+			}
+		}
+
+		if len(remaining) == 0 {
+			break
+		}
+
+		prices, err := p.Prices(ctx, remaining)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		for name, price := range prices {
+			result[name] = price
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// FormatISK formats ISK amount shortened to thousands, millions or billions ("1.25b ISK").
+func FormatISK(isk float64) string {
+	abs := isk
+	if abs < 0 {
+		abs = -abs
+	}
+
+	switch {
+	case abs >= 1e9:
+		return fmt.Sprintf("%.2fb ISK", isk/1e9)
+	case abs >= 1e6:
+		return fmt.Sprintf("%.2fm ISK", isk/1e6)
+	case abs >= 1e3:
+		return fmt.Sprintf("%.2fk ISK", isk/1e3)
+	default:
+		return fmt.Sprintf("%.2f ISK", isk)
+	}
+}
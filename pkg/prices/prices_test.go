@@ -0,0 +1,171 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"prices.csv":         "\ufefftypeID,name,price\n3898,Quafe,1500\n48121,Triglavian Survey Database,100000\n",
+		"prices_by_name.csv": "Name;Price\n",
+		"prices.json":        `[{"typeID":3898,"name":"Quafe","price":1500},{"name":"Triglavian Survey Database","price":100000}]`,
+		"prices_map.json":    `{"Quafe":1500,"Triglavian Survey Database":100000}`,
+		"bad_price.csv":      "name,price\nQuafe,cheap\n",
+		"prices.txt":         "Quafe 1500",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := Prices{"quafe": 1500, "Triglavian Survey Database": 100000}
+
+	for _, name := range []string{"prices.csv", "prices.json", "prices_map.json"} {
+		t.Run(name, func(t *testing.T) {
+			p, err := LoadFile(filepath.Join(dir, name))
+			if err != nil {
+				t.Fatalf("LoadFile() error = %v", err)
+			}
+
+			got, err := p.Prices(context.Background(), []string{"quafe", "Triglavian Survey Database", "Gila"})
+			if err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("Prices() = %v, %v, want %v", got, err, want)
+			}
+		})
+	}
+
+	for _, name := range []string{"prices_by_name.csv", "bad_price.csv", "prices.txt", "missing.csv"} {
+		if _, err := LoadFile(filepath.Join(dir, name)); err == nil {
+			t.Errorf("LoadFile(%s) expected error", name)
+		}
+	}
+
+	p, _ := LoadFile(filepath.Join(dir, "prices.csv"))
+	if price, ok := p.PriceByTypeID(3898); !ok || price != 1500 {
+		t.Errorf("PriceByTypeID() = %f, %t", price, ok)
+	}
+}
+
+// priceService is stand-in of HTTP price service.
+func priceService(t *testing.T, entries []Entry) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		requested := make(map[string]bool)
+
+		for _, name := range strings.Split(string(body), "\n") {
+			requested[strings.ToLower(name)] = true
+		}
+
+		var result []Entry
+
+		for _, e := range entries {
+			if requested[strings.ToLower(e.Name)] {
+				result = append(result, e)
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestHTTPProvider(t *testing.T) {
+	server := priceService(t, []Entry{{TypeID: 17715, Name: "GILA", Price: 2.8e8}, {Name: "Quafe", Price: 1500}})
+
+	got, err := NewHTTPProvider(server.Client(), server.URL).Prices(context.Background(), []string{"Gila", "Nergal"})
+	if err != nil {
+		t.Fatalf("Prices() error = %v", err)
+	}
+
+	if want := (Prices{"Gila": 2.8e8}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Prices() = %v, want %v", got, want)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	if _, err = NewHTTPProvider(failing.Client(), failing.URL).Prices(context.Background(), []string{"Gila"}); err == nil {
+		t.Error("Prices() of failing service expected error")
+	}
+}
+
+func TestChain(t *testing.T) {
+	local := NewFileProvider([]Entry{{Name: "Quafe", Price: 1000}})
+	server := priceService(t, []Entry{{Name: "Quafe", Price: 1500}, {Name: "Gila", Price: 2.8e8}})
+
+	p := Chain(local, NewHTTPProvider(server.Client(), server.URL))
+
+	value, missing, err := Lookup(context.Background(), p, map[string]int64{"Quafe": 2, "Gila": 1, "Nergal": 1})
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+
+	if value != 2.8e8+2000 || !reflect.DeepEqual(missing, []string{"Nergal"}) {
+		t.Errorf("Lookup() = %f, %v", value, missing)
+	}
+}
+
+func TestFittingItems(t *testing.T) {
+	eft := "[Gila, FFA]\r\n" +
+		"Drone Damage Amplifier II\r\n" +
+		"Drone Damage Amplifier II\r\n" +
+		"[Empty Low slot]\r\n\r\n" +
+		"Heavy Missile Launcher II, Scourge Fury Heavy Missile\r\n" +
+		"Large Shield Extender II /OFFLINE\r\n\r\n" +
+		"Hammerhead II x2\r\n\r\n" +
+		"Scourge Fury Heavy Missile x1500\r\n"
+
+	want := map[string]int64{
+		"Gila":                       1,
+		"Drone Damage Amplifier II":  2,
+		"Heavy Missile Launcher II":  1,
+		"Large Shield Extender II":   1,
+		"Hammerhead II":              2,
+		"Scourge Fury Heavy Missile": 1500,
+	}
+
+	if got := FittingItems(eft); !reflect.DeepEqual(got, want) {
+		t.Errorf("FittingItems() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatISK(t *testing.T) {
+	tests := map[float64]string{
+		950:     "950.00 ISK",
+		15200:   "15.20k ISK",
+		120.5e6: "120.50m ISK",
+		1.25e9:  "1.25b ISK",
+		-3.5e6:  "-3.50m ISK",
+	}
+
+	for isk, want := range tests {
+		if got := FormatISK(isk); got != want {
+			t.Errorf("FormatISK(%f) = %q, want %q", isk, got, want)
+		}
+	}
+}